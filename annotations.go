@@ -0,0 +1,141 @@
+package sarif
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultAnnotationLimit is the default number of annotations written by
+// WriteAnnotations, the number GitHub Actions shows for a job.
+const DefaultAnnotationLimit = 50
+
+// AnnotationOptions configures WriteAnnotations.
+type AnnotationOptions struct {
+	// MaxAnnotations limits the number of annotations;
+	// DefaultAnnotationLimit if zero, no limit if negative.
+	MaxAnnotations int
+	// IncludeSuppressed annotates suppressed results too.
+	IncludeSuppressed bool
+}
+
+// annotationCommands maps effective levels onto workflow commands. Results
+// of any other level are annotated as notices.
+var annotationCommands = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"note":    "notice",
+	"none":    "notice",
+}
+
+// WriteAnnotations writes the results of log to w as GitHub Actions workflow
+// commands, which show up as annotations of the workflow run and of the
+// changed lines of a pull request:
+//
+//	::error file=src/a.go,line=10,endLine=12,col=5,title=R1: Bad thing::message
+//
+// Errors come first, then warnings, then notices, so that the most severe
+// results are annotated when there are more than opts.MaxAnnotations. A
+// final line, which is not a command, counts the results by level and those
+// left out. Suppressed results are left out unless opts.IncludeSuppressed is
+// set. opts may be nil.
+func WriteAnnotations(w io.Writer, log *SARIF, opts *AnnotationOptions) error {
+	if opts == nil {
+		opts = &AnnotationOptions{}
+	}
+	limit := opts.MaxAnnotations
+	if limit == 0 {
+		limit = DefaultAnnotationLimit
+	}
+	type annotation struct {
+		command string
+		text    string
+	}
+	var annotations []annotation
+	counts := make(map[string]int)
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		for _, result := range run.Results {
+			if result == nil || result.IsSuppressed() && !opts.IncludeSuppressed {
+				continue
+			}
+			command := withDefault(annotationCommands[run.EffectiveLevel(result)], "notice")
+			counts[command]++
+			annotations = append(annotations, annotation{command, run.annotation(result, command)})
+		}
+	}
+	order := map[string]int{"error": 0, "warning": 1, "notice": 2}
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return order[annotations[i].command] < order[annotations[j].command]
+	})
+
+	var b strings.Builder
+	omitted := 0
+	for i, a := range annotations {
+		if limit >= 0 && i == limit {
+			omitted = len(annotations) - limit
+			break
+		}
+		b.WriteString(a.text + "\n")
+	}
+	fmt.Fprintf(&b, "%d %s: %d %s, %d %s, %d %s",
+		len(annotations), plural(len(annotations), "result", "results"),
+		counts["error"], plural(counts["error"], "error", "errors"),
+		counts["warning"], plural(counts["warning"], "warning", "warnings"),
+		counts["notice"], plural(counts["notice"], "notice", "notices"))
+	if omitted > 0 {
+		fmt.Fprintf(&b, "; %d not annotated, over the limit of %d", omitted, limit)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// annotation returns the workflow command that annotates result.
+func (run *Run) annotation(result *Result, command string) string {
+	var props []string
+	if pl := primaryLocation(result); pl != nil {
+		props = append(props, "file="+escapeAnnotationProperty(run.reportPath(pl.ArtifactLocation)))
+		if r := pl.Region; r != nil && r.StartLine > 0 {
+			props = append(props, "line="+strconv.Itoa(r.StartLine))
+			if r.EndLine > r.StartLine {
+				props = append(props, "endLine="+strconv.Itoa(r.EndLine))
+			}
+			if r.StartColumn > 0 {
+				props = append(props, "col="+strconv.Itoa(r.StartColumn))
+				if r.EndColumn > 0 {
+					props = append(props, "endColumn="+strconv.Itoa(r.EndColumn))
+				}
+			}
+		}
+	}
+	if title := run.ruleID(result); title != "" {
+		if rule := run.rule(result); rule != nil && rule.ShortDescription != nil && rule.ShortDescription.Text != "" {
+			title += ": " + rule.ShortDescription.Text
+		}
+		props = append(props, "title="+escapeAnnotationProperty(title))
+	}
+	msg, err := run.FormatMessage(result, false)
+	if err != nil {
+		msg = "(" + err.Error() + ")"
+	}
+	cmd := "::" + command
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return cmd + "::" + escapeAnnotationData(msg)
+}
+
+// escapeAnnotationData escapes the message of a workflow command.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
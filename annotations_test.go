@@ -0,0 +1,80 @@
+package sarif
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnnotations(t *testing.T) {
+	tests := []struct {
+		name   string
+		golden string
+		opts   *AnnotationOptions
+	}{
+		{"default", "annotations.txt", nil},
+		{"suppressed and limited", "annotations-limited.txt", &AnnotationOptions{MaxAnnotations: 1, IncludeSuppressed: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteAnnotations(&buf, readTestLog(t, "sample.sarif"), tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
+
+func TestAnnotationCommand(t *testing.T) {
+	tests := []struct {
+		level string
+		kind  string
+		want  string
+	}{
+		{"error", "", "::error "},
+		{"warning", "", "::warning "},
+		{"note", "", "::notice "},
+		{"none", "", "::notice "},
+		{"", "", "::warning "},
+		{"", "pass", "::notice "},
+		{"critical", "", "::notice "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level+"/"+tt.kind, func(t *testing.T) {
+			log := &SARIF{Runs: []*Run{{Results: []*Result{{
+				Level:   tt.level,
+				Kind:    tt.kind,
+				Message: &Message{Text: "m"},
+				Locations: []*Location{{PhysicalLocation: &PhysicalLocation{
+					ArtifactLocation: &ArtifactLocation{Uri: "x.go", Index: -1},
+				}}},
+			}}}}}
+			var buf bytes.Buffer
+			if err := WriteAnnotations(&buf, log, nil); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(buf.String(), tt.want) {
+				t.Errorf("annotation = %q, want it to start with %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeAnnotation(t *testing.T) {
+	tests := []struct {
+		in, data, property string
+	}{
+		{"plain", "plain", "plain"},
+		{"50%\r\nnext", "50%25%0D%0Anext", "50%25%0D%0Anext"},
+		{"a:b,c", "a:b,c", "a%3Ab%2Cc"},
+	}
+	for _, tt := range tests {
+		if got := escapeAnnotationData(tt.in); got != tt.data {
+			t.Errorf("escapeAnnotationData(%q) = %q, want %q", tt.in, got, tt.data)
+		}
+		if got := escapeAnnotationProperty(tt.in); got != tt.property {
+			t.Errorf("escapeAnnotationProperty(%q) = %q, want %q", tt.in, got, tt.property)
+		}
+	}
+}
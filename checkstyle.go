@@ -0,0 +1,141 @@
+package sarif
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// checkstyleSeverities maps effective levels onto Checkstyle severities, and
+// back through levelsByCheckstyleSeverity.
+var checkstyleSeverities = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"note":    "info",
+	"none":    "ignore",
+}
+
+var levelsByCheckstyleSeverity = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"info":    "note",
+	"ignore":  "none",
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr,omitempty"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr,omitempty"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"`
+}
+
+// WriteCheckstyle writes the results of log to w as Checkstyle XML, with a
+// <file> element per artifact, in order of first appearance, and an <error>
+// element per result at its first location in an artifact. The source of an
+// error is the rule id of its result and its severity follows the result's
+// effective level. Results without a location in an artifact are left out,
+// as Checkstyle has no place for them.
+func WriteCheckstyle(w io.Writer, log *SARIF) error {
+	report := &checkstyleReport{Version: "4.3"}
+	files := make(map[string]*checkstyleFile)
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		for _, result := range run.Results {
+			if result == nil {
+				continue
+			}
+			pl := primaryLocation(result)
+			if pl == nil {
+				continue
+			}
+			name := run.reportPath(pl.ArtifactLocation)
+			file := files[name]
+			if file == nil {
+				file = &checkstyleFile{Name: name}
+				files[name] = file
+				report.Files = append(report.Files, file)
+			}
+			msg, err := run.FormatMessage(result, false)
+			if err != nil {
+				msg = "(" + err.Error() + ")"
+			}
+			e := &checkstyleError{
+				Severity: checkstyleSeverities[run.EffectiveLevel(result)],
+				Message:  msg,
+				Source:   run.ruleID(result),
+			}
+			if pl.Region != nil {
+				e.Line = pl.Region.StartLine
+				e.Column = pl.Region.StartColumn
+			}
+			file.Errors = append(file.Errors, e)
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadCheckstyle converts the Checkstyle XML read from r into a SARIF log with
+// a single run of a tool named "checkstyle". Each distinct source of the
+// errors becomes a rule of the driver, and each error a result whose level
+// follows its severity; errors without a severity are warnings.
+func ReadCheckstyle(r io.Reader) (*SARIF, error) {
+	var report checkstyleReport
+	if err := xml.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("reading Checkstyle XML: %w", err)
+	}
+	log := newImportedLog()
+	run := newImportedRun("checkstyle")
+	log.Runs = append(log.Runs, run)
+	rules := make(map[string]int)
+	for _, file := range report.Files {
+		if file == nil {
+			continue
+		}
+		for _, e := range file.Errors {
+			if e == nil {
+				continue
+			}
+			result := &Result{
+				RuleId:    e.Source,
+				RuleIndex: -1,
+				Level:     withDefault(levelsByCheckstyleSeverity[strings.ToLower(e.Severity)], "warning"),
+				Message:   &Message{Text: e.Message},
+				Rank:      -1,
+			}
+			if e.Source != "" {
+				result.RuleIndex = run.importedRule(rules, e.Source)
+			}
+			pl := &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: pathURI(file.Name), Index: -1}}
+			if e.Line > 0 {
+				pl.Region = &Region{StartLine: e.Line, StartColumn: e.Column}
+			}
+			result.Locations = []*Location{{PhysicalLocation: pl}}
+			run.Results = append(run.Results, result)
+		}
+	}
+	return log, nil
+}
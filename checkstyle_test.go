@@ -0,0 +1,35 @@
+package sarif
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCheckstyle(&buf, readTestLog(t, "sample.sarif")); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "checkstyle-export.xml", buf.Bytes())
+}
+
+func TestReadCheckstyle(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "checkstyle.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := ReadCheckstyle(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "checkstyle-import.sarif", marshalTestLog(t, log))
+}
+
+func TestReadCheckstyleMalformed(t *testing.T) {
+	if _, err := ReadCheckstyle(strings.NewReader(`<checkstyle><file name="a">`)); err == nil {
+		t.Error("ReadCheckstyle() succeeded, want an error")
+	}
+}
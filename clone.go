@@ -0,0 +1,77 @@
+package sarif
+
+// Clone returns a deep copy of strct.
+func (strct *PropertyBag) Clone() *PropertyBag {
+	if strct == nil {
+		return nil
+	}
+	c := &PropertyBag{Tags: cloneStrings(strct.Tags)}
+	if strct.AdditionalProperties != nil {
+		c.AdditionalProperties = cloneValue(strct.AdditionalProperties).(map[string]interface{})
+	}
+	return c
+}
+
+// cloneValue deep copies a decoded JSON value.
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			c[k] = cloneValue(e)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = cloneValue(e)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+type cloner[T any] interface {
+	Clone() T
+}
+
+func cloneSlice[T cloner[T]](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	c := make([]T, len(s))
+	for i, e := range s {
+		c[i] = e.Clone()
+	}
+	return c
+}
+
+func cloneMap[T cloner[T]](m map[string]T) map[string]T {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]T, len(m))
+	for k, v := range m {
+		c[k] = v.Clone()
+	}
+	return c
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+func cloneStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
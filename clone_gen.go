@@ -0,0 +1,724 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package sarif
+
+// Clone returns a deep copy of strct.
+func (strct *Address) Clone() *Address {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Artifact) Clone() *Artifact {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Contents = strct.Contents.Clone()
+	c.Description = strct.Description.Clone()
+	c.Hashes = cloneStringMap(strct.Hashes)
+	c.Location = strct.Location.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Roles = cloneStrings(strct.Roles)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ArtifactChange) Clone() *ArtifactChange {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.ArtifactLocation = strct.ArtifactLocation.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Replacements = cloneSlice(strct.Replacements)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ArtifactContent) Clone() *ArtifactContent {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	c.Rendered = strct.Rendered.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ArtifactLocation) Clone() *ArtifactLocation {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Attachment) Clone() *Attachment {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.ArtifactLocation = strct.ArtifactLocation.Clone()
+	c.Description = strct.Description.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Rectangles = cloneSlice(strct.Rectangles)
+	c.Regions = cloneSlice(strct.Regions)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *CodeFlow) Clone() *CodeFlow {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.ThreadFlows = cloneSlice(strct.ThreadFlows)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ConfigurationOverride) Clone() *ConfigurationOverride {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Configuration = strct.Configuration.Clone()
+	c.Descriptor = strct.Descriptor.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Conversion) Clone() *Conversion {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.AnalysisToolLogFiles = cloneSlice(strct.AnalysisToolLogFiles)
+	c.Invocation = strct.Invocation.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Tool = strct.Tool.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Edge) Clone() *Edge {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Label = strct.Label.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *EdgeTraversal) Clone() *EdgeTraversal {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.FinalState = cloneMap(strct.FinalState)
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Exception) Clone() *Exception {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.InnerExceptions = cloneSlice(strct.InnerExceptions)
+	c.Properties = strct.Properties.Clone()
+	c.Stack = strct.Stack.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ExternalProperties) Clone() *ExternalProperties {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Addresses = cloneSlice(strct.Addresses)
+	c.Artifacts = cloneSlice(strct.Artifacts)
+	c.Conversion = strct.Conversion.Clone()
+	c.Driver = strct.Driver.Clone()
+	c.Extensions = cloneSlice(strct.Extensions)
+	c.ExternalizedProperties = strct.ExternalizedProperties.Clone()
+	c.Graphs = cloneSlice(strct.Graphs)
+	c.Invocations = cloneSlice(strct.Invocations)
+	c.LogicalLocations = cloneSlice(strct.LogicalLocations)
+	c.Policies = cloneSlice(strct.Policies)
+	c.Properties = strct.Properties.Clone()
+	c.Results = cloneSlice(strct.Results)
+	c.Taxonomies = cloneSlice(strct.Taxonomies)
+	c.ThreadFlowLocations = cloneSlice(strct.ThreadFlowLocations)
+	c.Translations = cloneSlice(strct.Translations)
+	c.WebRequests = cloneSlice(strct.WebRequests)
+	c.WebResponses = cloneSlice(strct.WebResponses)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ExternalPropertyFileReference) Clone() *ExternalPropertyFileReference {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Location = strct.Location.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ExternalPropertyFileReferences) Clone() *ExternalPropertyFileReferences {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Addresses = cloneSlice(strct.Addresses)
+	c.Artifacts = cloneSlice(strct.Artifacts)
+	c.Conversion = strct.Conversion.Clone()
+	c.Driver = strct.Driver.Clone()
+	c.Extensions = cloneSlice(strct.Extensions)
+	c.ExternalizedProperties = strct.ExternalizedProperties.Clone()
+	c.Graphs = cloneSlice(strct.Graphs)
+	c.Invocations = cloneSlice(strct.Invocations)
+	c.LogicalLocations = cloneSlice(strct.LogicalLocations)
+	c.Policies = cloneSlice(strct.Policies)
+	c.Properties = strct.Properties.Clone()
+	c.Results = cloneSlice(strct.Results)
+	c.Taxonomies = cloneSlice(strct.Taxonomies)
+	c.ThreadFlowLocations = cloneSlice(strct.ThreadFlowLocations)
+	c.Translations = cloneSlice(strct.Translations)
+	c.WebRequests = cloneSlice(strct.WebRequests)
+	c.WebResponses = cloneSlice(strct.WebResponses)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Fix) Clone() *Fix {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.ArtifactChanges = cloneSlice(strct.ArtifactChanges)
+	c.Description = strct.Description.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Graph) Clone() *Graph {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.Edges = cloneSlice(strct.Edges)
+	c.Nodes = cloneSlice(strct.Nodes)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *GraphTraversal) Clone() *GraphTraversal {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.EdgeTraversals = cloneSlice(strct.EdgeTraversals)
+	c.ImmutableState = cloneMap(strct.ImmutableState)
+	c.InitialState = cloneMap(strct.InitialState)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Invocation) Clone() *Invocation {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Arguments = cloneStrings(strct.Arguments)
+	c.EnvironmentVariables = cloneStringMap(strct.EnvironmentVariables)
+	c.ExecutableLocation = strct.ExecutableLocation.Clone()
+	c.NotificationConfigurationOverrides = cloneSlice(strct.NotificationConfigurationOverrides)
+	c.Properties = strct.Properties.Clone()
+	c.ResponseFiles = cloneSlice(strct.ResponseFiles)
+	c.RuleConfigurationOverrides = cloneSlice(strct.RuleConfigurationOverrides)
+	c.Stderr = strct.Stderr.Clone()
+	c.Stdin = strct.Stdin.Clone()
+	c.Stdout = strct.Stdout.Clone()
+	c.StdoutStderr = strct.StdoutStderr.Clone()
+	c.ToolConfigurationNotifications = cloneSlice(strct.ToolConfigurationNotifications)
+	c.ToolExecutionNotifications = cloneSlice(strct.ToolExecutionNotifications)
+	c.WorkingDirectory = strct.WorkingDirectory.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Location) Clone() *Location {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Annotations = cloneSlice(strct.Annotations)
+	c.LogicalLocations = cloneSlice(strct.LogicalLocations)
+	c.Message = strct.Message.Clone()
+	c.PhysicalLocation = strct.PhysicalLocation.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Relationships = cloneSlice(strct.Relationships)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *LocationRelationship) Clone() *LocationRelationship {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.Kinds = cloneStrings(strct.Kinds)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *LogicalLocation) Clone() *LogicalLocation {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Message) Clone() *Message {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Arguments = cloneStrings(strct.Arguments)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *MultiformatMessageString) Clone() *MultiformatMessageString {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Node) Clone() *Node {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Children = cloneSlice(strct.Children)
+	c.Label = strct.Label.Clone()
+	c.Location = strct.Location.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Notification) Clone() *Notification {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.AssociatedRule = strct.AssociatedRule.Clone()
+	c.Descriptor = strct.Descriptor.Clone()
+	c.Exception = strct.Exception.Clone()
+	c.Locations = cloneSlice(strct.Locations)
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *PhysicalLocation) Clone() *PhysicalLocation {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Address = strct.Address.Clone()
+	c.ArtifactLocation = strct.ArtifactLocation.Clone()
+	c.ContextRegion = strct.ContextRegion.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Region = strct.Region.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Rectangle) Clone() *Rectangle {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Region) Clone() *Region {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Snippet = strct.Snippet.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Replacement) Clone() *Replacement {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.DeletedRegion = strct.DeletedRegion.Clone()
+	c.InsertedContent = strct.InsertedContent.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ReportingConfiguration) Clone() *ReportingConfiguration {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Parameters = strct.Parameters.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ReportingDescriptor) Clone() *ReportingDescriptor {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.DefaultConfiguration = strct.DefaultConfiguration.Clone()
+	c.DeprecatedGuids = cloneStrings(strct.DeprecatedGuids)
+	c.DeprecatedIds = cloneStrings(strct.DeprecatedIds)
+	c.DeprecatedNames = cloneStrings(strct.DeprecatedNames)
+	c.FullDescription = strct.FullDescription.Clone()
+	c.Help = strct.Help.Clone()
+	c.MessageStrings = cloneMap(strct.MessageStrings)
+	c.Properties = strct.Properties.Clone()
+	c.Relationships = cloneSlice(strct.Relationships)
+	c.ShortDescription = strct.ShortDescription.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ReportingDescriptorReference) Clone() *ReportingDescriptorReference {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	c.ToolComponent = strct.ToolComponent.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ReportingDescriptorRelationship) Clone() *ReportingDescriptorRelationship {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.Kinds = cloneStrings(strct.Kinds)
+	c.Properties = strct.Properties.Clone()
+	c.Target = strct.Target.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Result) Clone() *Result {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.AnalysisTarget = strct.AnalysisTarget.Clone()
+	c.Attachments = cloneSlice(strct.Attachments)
+	c.CodeFlows = cloneSlice(strct.CodeFlows)
+	c.Fingerprints = cloneStringMap(strct.Fingerprints)
+	c.Fixes = cloneSlice(strct.Fixes)
+	c.GraphTraversals = cloneSlice(strct.GraphTraversals)
+	c.Graphs = cloneSlice(strct.Graphs)
+	c.Locations = cloneSlice(strct.Locations)
+	c.Message = strct.Message.Clone()
+	c.PartialFingerprints = cloneStringMap(strct.PartialFingerprints)
+	c.Properties = strct.Properties.Clone()
+	c.Provenance = strct.Provenance.Clone()
+	c.RelatedLocations = cloneSlice(strct.RelatedLocations)
+	c.Rule = strct.Rule.Clone()
+	c.Stacks = cloneSlice(strct.Stacks)
+	c.Suppressions = cloneSlice(strct.Suppressions)
+	c.Taxa = cloneSlice(strct.Taxa)
+	c.WebRequest = strct.WebRequest.Clone()
+	c.WebResponse = strct.WebResponse.Clone()
+	c.WorkItemUris = cloneStrings(strct.WorkItemUris)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ResultProvenance) Clone() *ResultProvenance {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.ConversionSources = cloneSlice(strct.ConversionSources)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Run) Clone() *Run {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Addresses = cloneSlice(strct.Addresses)
+	c.Artifacts = cloneSlice(strct.Artifacts)
+	c.AutomationDetails = strct.AutomationDetails.Clone()
+	c.Conversion = strct.Conversion.Clone()
+	c.ExternalPropertyFileReferences = strct.ExternalPropertyFileReferences.Clone()
+	c.Graphs = cloneSlice(strct.Graphs)
+	c.Invocations = cloneSlice(strct.Invocations)
+	c.LogicalLocations = cloneSlice(strct.LogicalLocations)
+	c.NewlineSequences = cloneStrings(strct.NewlineSequences)
+	c.OriginalUriBaseIds = cloneMap(strct.OriginalUriBaseIds)
+	c.Policies = cloneSlice(strct.Policies)
+	c.Properties = strct.Properties.Clone()
+	c.RedactionTokens = cloneStrings(strct.RedactionTokens)
+	c.Results = cloneSlice(strct.Results)
+	c.RunAggregates = cloneSlice(strct.RunAggregates)
+	c.SpecialLocations = strct.SpecialLocations.Clone()
+	c.Taxonomies = cloneSlice(strct.Taxonomies)
+	c.ThreadFlowLocations = cloneSlice(strct.ThreadFlowLocations)
+	c.Tool = strct.Tool.Clone()
+	c.Translations = cloneSlice(strct.Translations)
+	c.VersionControlProvenance = cloneSlice(strct.VersionControlProvenance)
+	c.WebRequests = cloneSlice(strct.WebRequests)
+	c.WebResponses = cloneSlice(strct.WebResponses)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *RunAutomationDetails) Clone() *RunAutomationDetails {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Description = strct.Description.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *SARIF) Clone() *SARIF {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.InlineExternalProperties = cloneSlice(strct.InlineExternalProperties)
+	c.Properties = strct.Properties.Clone()
+	c.Runs = cloneSlice(strct.Runs)
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *SpecialLocations) Clone() *SpecialLocations {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.DisplayBase = strct.DisplayBase.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Stack) Clone() *Stack {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Frames = cloneSlice(strct.Frames)
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *StackFrame) Clone() *StackFrame {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Location = strct.Location.Clone()
+	c.Parameters = cloneStrings(strct.Parameters)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Suppression) Clone() *Suppression {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Location = strct.Location.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ThreadFlow) Clone() *ThreadFlow {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.ImmutableState = cloneMap(strct.ImmutableState)
+	c.InitialState = cloneMap(strct.InitialState)
+	c.Locations = cloneSlice(strct.Locations)
+	c.Message = strct.Message.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ThreadFlowLocation) Clone() *ThreadFlowLocation {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Kinds = cloneStrings(strct.Kinds)
+	c.Location = strct.Location.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.Stack = strct.Stack.Clone()
+	c.State = cloneMap(strct.State)
+	c.Taxa = cloneSlice(strct.Taxa)
+	c.WebRequest = strct.WebRequest.Clone()
+	c.WebResponse = strct.WebResponse.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *Tool) Clone() *Tool {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Driver = strct.Driver.Clone()
+	c.Extensions = cloneSlice(strct.Extensions)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ToolComponent) Clone() *ToolComponent {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.AssociatedComponent = strct.AssociatedComponent.Clone()
+	c.FullDescription = strct.FullDescription.Clone()
+	c.GlobalMessageStrings = cloneMap(strct.GlobalMessageStrings)
+	c.Locations = cloneSlice(strct.Locations)
+	c.Notifications = cloneSlice(strct.Notifications)
+	c.Properties = strct.Properties.Clone()
+	c.Rules = cloneSlice(strct.Rules)
+	c.ShortDescription = strct.ShortDescription.Clone()
+	c.SupportedTaxonomies = cloneSlice(strct.SupportedTaxonomies)
+	c.Taxa = cloneSlice(strct.Taxa)
+	c.TranslationMetadata = strct.TranslationMetadata.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *ToolComponentReference) Clone() *ToolComponentReference {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *TranslationMetadata) Clone() *TranslationMetadata {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.FullDescription = strct.FullDescription.Clone()
+	c.Properties = strct.Properties.Clone()
+	c.ShortDescription = strct.ShortDescription.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *VersionControlDetails) Clone() *VersionControlDetails {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.MappedTo = strct.MappedTo.Clone()
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *WebRequest) Clone() *WebRequest {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Body = strct.Body.Clone()
+	c.Headers = cloneStringMap(strct.Headers)
+	c.Parameters = cloneStringMap(strct.Parameters)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of strct.
+func (strct *WebResponse) Clone() *WebResponse {
+	if strct == nil {
+		return nil
+	}
+	c := *strct
+	c.Body = strct.Body.Clone()
+	c.Headers = cloneStringMap(strct.Headers)
+	c.Properties = strct.Properties.Clone()
+	return &c
+}
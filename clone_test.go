@@ -0,0 +1,69 @@
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const cloneTestLog = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "t", "rules": [{"id": "R1", "properties": {"tags": ["security"], "precision": "high"}}]}},
+    "originalUriBaseIds": {"SRC": {"uri": "file:///src/"}},
+    "results": [{
+      "ruleId": "R1",
+      "message": {"text": "m", "arguments": ["a"]},
+      "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go", "uriBaseId": "SRC"}, "region": {"startLine": 3}}}],
+      "partialFingerprints": {"h": "1"},
+      "properties": {"nested": {"list": [1, {"k": "v"}]}}
+    }]
+  }]
+}`
+
+func TestClone(t *testing.T) {
+	var log SARIF
+	if err := json.Unmarshal([]byte(cloneTestLog), &log); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		mutate func(*SARIF)
+	}{
+		{"result slice", func(l *SARIF) { l.Runs[0].Results = append(l.Runs[0].Results[:0], &Result{}) }},
+		{"message arguments", func(l *SARIF) { l.Runs[0].Results[0].Message.Arguments[0] = "b" }},
+		{"region", func(l *SARIF) { l.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine = 4 }},
+		{"string map", func(l *SARIF) { l.Runs[0].Results[0].PartialFingerprints["h"] = "2" }},
+		{"object map", func(l *SARIF) { l.Runs[0].OriginalUriBaseIds["SRC"].Uri = "file:///elsewhere/" }},
+		{"tags", func(l *SARIF) { l.Runs[0].Tool.Driver.Rules[0].Properties.Tags[0] = "style" }},
+		{"nested property", func(l *SARIF) {
+			nested := l.Runs[0].Results[0].Properties.AdditionalProperties["nested"].(map[string]interface{})
+			nested["list"].([]interface{})[1].(map[string]interface{})["k"] = "w"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := log.Clone()
+			if diff := Diff(&log, c); diff != nil {
+				t.Fatalf("clone differs at %q", diff)
+			}
+			tt.mutate(c)
+			if Diff(&log, c) == nil {
+				t.Fatal("mutating the clone left it equal to the original")
+			}
+			var fresh SARIF
+			if err := json.Unmarshal([]byte(cloneTestLog), &fresh); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(&log, &fresh); diff != nil {
+				t.Errorf("mutating the clone changed the original at %q", diff)
+			}
+		})
+	}
+}
+
+func TestCloneNil(t *testing.T) {
+	var r *Result
+	if r.Clone() != nil {
+		t.Error("Clone of a nil result is not nil")
+	}
+}
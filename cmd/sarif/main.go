@@ -0,0 +1,514 @@
+// Command sarif inspects and transforms SARIF log files.
+//
+// Usage:
+//
+//	sarif <command> [arguments]
+//
+// The commands are:
+//
+//	filter      keep only the results matching an expression
+//	summary     print statistics about the results
+//	gate        check the results against a quality gate
+//	html        render a self-contained HTML report
+//	markdown    render a Markdown report for pull requests and job summaries
+//	table       export the results as CSV or TSV
+//	junit       convert the results to a JUnit XML report
+//	annotations print GitHub Actions workflow commands that annotate the results
+//	codeclimate convert the results to Code Climate issues for GitLab
+//	gitlab-sast convert the results to a GitLab SAST security report
+//	checkstyle  convert the results to Checkstyle XML
+//	import      convert a report of another format to SARIF
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tjgurwara99/sarif/v2"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []*command{
+	filterCommand,
+	summaryCommand,
+	gateCommand,
+	htmlCommand,
+	markdownCommand,
+	tableCommand,
+	junitCommand,
+	annotationsCommand,
+	codeClimateCommand,
+	gitLabSASTCommand,
+	checkstyleCommand,
+	importCommand,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				var exit exitError
+				if errors.As(err, &exit) {
+					os.Exit(int(exit))
+				}
+				fmt.Fprintf(os.Stderr, "sarif %s: %v\n", cmd.name, err)
+				os.Exit(2)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "sarif: unknown command %q\n", os.Args[1])
+	usage()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: sarif <command> [arguments]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+	}
+	os.Exit(2)
+}
+
+// exitError makes a command exit with the given status without printing an
+// error message.
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+// readLog reads a SARIF log from the named file, or from standard input if
+// name is empty or "-".
+func readLog(name string) (*sarif.SARIF, error) {
+	var r io.Reader = os.Stdin
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	log := &sarif.SARIF{}
+	if err := json.NewDecoder(r).Decode(log); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", displayName(name), err)
+	}
+	return log, nil
+}
+
+func displayName(name string) string {
+	if name == "" || name == "-" {
+		return "standard input"
+	}
+	return name
+}
+
+func writeLog(w io.Writer, log *sarif.SARIF) error {
+	b, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// inputArg returns the single optional file argument of a command.
+func inputArg(fs *flag.FlagSet) (string, error) {
+	switch fs.NArg() {
+	case 0:
+		return "", nil
+	case 1:
+		return fs.Arg(0), nil
+	}
+	return "", fmt.Errorf("too many arguments: %s", strings.Join(fs.Args(), " "))
+}
+
+var filterCommand = &command{
+	name:  "filter",
+	usage: "filter -e expr [file]   keep only the results matching expr",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("filter", flag.ExitOnError)
+		src := fs.String("e", "", "filter `expression`, e.g. 'level == \"error\" && !suppressed'")
+		fs.Parse(args)
+		if *src == "" {
+			fs.Usage()
+			return exitError(2)
+		}
+		expr, err := sarif.ParseExpr(*src)
+		if err != nil {
+			var exprErr *sarif.ExprError
+			if errors.As(err, &exprErr) {
+				fmt.Fprintf(os.Stderr, "sarif filter: invalid expression: %s\n  %s\n  %s^\n",
+					exprErr.Msg, *src, strings.Repeat(" ", exprErr.Pos-1))
+				return exitError(2)
+			}
+			return err
+		}
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return writeLog(os.Stdout, sarif.Filter(log, sarif.NewQuery(expr.Predicate())))
+	},
+}
+
+var summaryCommand = &command{
+	name:  "summary",
+	usage: "summary [-format text|markdown|json] [file]   print statistics about the results",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("summary", flag.ExitOnError)
+		format := fs.String("format", "text", "output `format`: text, markdown or json")
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		s := sarif.Summarize(log)
+		switch *format {
+		case "text":
+			return s.WriteText(os.Stdout)
+		case "markdown":
+			return s.WriteMarkdown(os.Stdout)
+		case "json":
+			return s.WriteJSON(os.Stdout)
+		}
+		return fmt.Errorf("unknown format %q", *format)
+	},
+}
+
+var gateCommand = &command{
+	name:  "gate",
+	usage: "gate -policy gate.json [-list n] [file]   exit 1 if the results fail the quality gate",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("gate", flag.ExitOnError)
+		policy := fs.String("policy", "", "gate policy `file` in JSON")
+		list := fs.Int("list", 10, "list at most `n` results per failed condition, all if negative")
+		fs.Parse(args)
+		if *policy == "" {
+			fs.Usage()
+			return exitError(2)
+		}
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(*policy)
+		if err != nil {
+			return err
+		}
+		gate, err := sarif.ReadGate(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		outcome, err := gate.Evaluate(log)
+		if err != nil {
+			return err
+		}
+		if err := outcome.Explain(os.Stdout, *list); err != nil {
+			return err
+		}
+		if !outcome.Passed {
+			return exitError(1)
+		}
+		return nil
+	},
+}
+
+var htmlCommand = &command{
+	name:  "html",
+	usage: "html [-title title] [-src dir] [-context n] [file]   render an HTML report",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("html", flag.ExitOnError)
+		title := fs.String("title", "", "report `title`")
+		src := fs.String("src", ".", "`directory` that relative artifact URIs are resolved against for source snippets")
+		context := fs.Int("context", 2, "`lines` of context around results")
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		root, err := filepath.Abs(*src)
+		if err != nil {
+			return err
+		}
+		opts := &sarif.HTMLOptions{Title: *title, ContextLines: *context, Source: sourceReader(root)}
+		return sarif.WriteHTML(os.Stdout, log, opts)
+	},
+}
+
+// sourceReader returns an HTMLOptions.Source that reads artifacts below the
+// absolute directory root: relative URIs are resolved against root, and file
+// URIs are only read if they point below it, so that a log cannot pull other
+// local files into the report.
+func sourceReader(root string) func(uri string) ([]byte, error) {
+	return func(uri string) ([]byte, error) {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		var name string
+		switch {
+		case u.Scheme == "file" && (u.Host == "" || u.Host == "localhost"):
+			name = filepath.Clean(filepath.FromSlash(u.Path))
+		case u.Scheme == "" && u.Host == "" && !path.IsAbs(u.Path):
+			name = filepath.Join(root, filepath.FromSlash(u.Path))
+		default:
+			return nil, fmt.Errorf("cannot read %s", uri)
+		}
+		if rel, err := filepath.Rel(root, name); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%s is outside of %s", uri, root)
+		}
+		return os.ReadFile(name)
+	}
+}
+
+var markdownCommand = &command{
+	name:  "markdown",
+	usage: "markdown [-title title] [-new] [-max-bytes n] [-max-results n] [file]   render a Markdown report",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("markdown", flag.ExitOnError)
+		opts := &sarif.MarkdownOptions{}
+		fs.StringVar(&opts.Title, "title", "", "report `title`")
+		fs.BoolVar(&opts.NewOnly, "new", false, "only report results whose baseline state is new")
+		fs.IntVar(&opts.MaxBytes, "max-bytes", sarif.DefaultMarkdownLimit, "truncate the report to `n` bytes")
+		fs.IntVar(&opts.MaxResultsPerRule, "max-results", 0, "list at most `n` results per rule, all if 0")
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteMarkdown(os.Stdout, log, opts)
+	},
+}
+
+var tableCommand = &command{
+	name:  "table",
+	usage: "table [-tsv] [-columns c1,c2] [-locations first|each|join] [file]   export the results as CSV or TSV",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("table", flag.ExitOnError)
+		tsv := fs.Bool("tsv", false, "write tab-separated values instead of CSV")
+		columns := fs.String("columns", strings.Join(sarif.DefaultTableColumns, ","), "comma-separated `columns`; properties.<key> selects a property")
+		locations := fs.String("locations", "first", "how to flatten results with several locations: `first`, each or join")
+		noHeader := fs.Bool("no-header", false, "omit the header row")
+		raw := fs.Bool("raw", false, "do not escape cells that spreadsheets would evaluate as formulas")
+		fs.Parse(args)
+		opts := &sarif.TableOptions{Columns: strings.Split(*columns, ","), NoHeader: *noHeader, EscapeFormulas: !*raw}
+		if *tsv {
+			opts.Comma = '\t'
+		}
+		switch *locations {
+		case "first":
+			opts.Locations = sarif.FirstLocation
+		case "each":
+			opts.Locations = sarif.EachLocation
+		case "join":
+			opts.Locations = sarif.JoinLocations
+		default:
+			return fmt.Errorf("unknown location strategy %q", *locations)
+		}
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteTable(os.Stdout, log, opts)
+	},
+}
+
+var junitCommand = &command{
+	name:  "junit",
+	usage: "junit [-name name] [-per-result] [-fail levels] [file]   convert the results to a JUnit XML report",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("junit", flag.ExitOnError)
+		name := fs.String("name", "", "`name` of the test suites")
+		perResult := fs.Bool("per-result", false, "write a test case per result instead of per rule")
+		fail := fs.String("fail", "error,warning", "comma-separated effective `levels` of failing results")
+		fs.Parse(args)
+		opts := &sarif.JUnitOptions{Name: *name, FailureLevels: strings.Split(*fail, ",")}
+		if *perResult {
+			opts.Cases = sarif.ResultCases
+		}
+		file, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(file)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteJUnit(os.Stdout, log, opts)
+	},
+}
+
+var annotationsCommand = &command{
+	name:  "annotations",
+	usage: "annotations [-max n] [-suppressed] [file]   print GitHub Actions workflow commands that annotate the results",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("annotations", flag.ExitOnError)
+		opts := &sarif.AnnotationOptions{}
+		fs.IntVar(&opts.MaxAnnotations, "max", sarif.DefaultAnnotationLimit, "write at most `n` annotations, all if negative")
+		fs.BoolVar(&opts.IncludeSuppressed, "suppressed", false, "annotate suppressed results too")
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteAnnotations(os.Stdout, log, opts)
+	},
+}
+
+var codeClimateCommand = &command{
+	name:  "codeclimate",
+	usage: "codeclimate [file]   convert the results to Code Climate issues for GitLab Code Quality",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("codeclimate", flag.ExitOnError)
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteCodeClimate(os.Stdout, log)
+	},
+}
+
+var gitLabSASTCommand = &command{
+	name:  "gitlab-sast",
+	usage: "gitlab-sast [-analyzer id] [file]   convert the results to a GitLab SAST security report",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("gitlab-sast", flag.ExitOnError)
+		analyzer := fs.String("analyzer", "", "`id` of the analyzer that produced the report, the scanner if empty")
+		analyzerVersion := fs.String("analyzer-version", "", "`version` of the analyzer")
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		opts := &sarif.GitLabSASTOptions{}
+		if *analyzer != "" {
+			opts.Analyzer = &sarif.GitLabTool{ID: *analyzer, Version: *analyzerVersion}
+		}
+		return sarif.WriteGitLabSAST(os.Stdout, log, opts)
+	},
+}
+
+var checkstyleCommand = &command{
+	name:  "checkstyle",
+	usage: "checkstyle [file]   convert the results to Checkstyle XML",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("checkstyle", flag.ExitOnError)
+		fs.Parse(args)
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		log, err := readLog(name)
+		if err != nil {
+			return err
+		}
+		return sarif.WriteCheckstyle(os.Stdout, log)
+	},
+}
+
+// importers maps the formats of the import command onto their readers.
+var importers = map[string]func(io.Reader) (*sarif.SARIF, error){
+	"checkstyle":    sarif.ReadCheckstyle,
+	"codeclimate":   sarif.ReadCodeClimate,
+	"golangci-lint": sarif.ReadGolangCILint,
+}
+
+var importCommand = &command{
+	name:  "import",
+	usage: "import -format checkstyle|codeclimate|golangci-lint [file]   convert a report of another format to SARIF",
+	run: func(args []string) error {
+		fs := flag.NewFlagSet("import", flag.ExitOnError)
+		format := fs.String("format", "", "`format` of the report: checkstyle, codeclimate or golangci-lint")
+		fs.Parse(args)
+		read := importers[*format]
+		if read == nil {
+			if *format == "" {
+				fs.Usage()
+				return exitError(2)
+			}
+			return fmt.Errorf("unknown format %q", *format)
+		}
+		name, err := inputArg(fs)
+		if err != nil {
+			return err
+		}
+		var r io.Reader = os.Stdin
+		if name != "" && name != "-" {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		log, err := read(r)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", displayName(name), err)
+		}
+		if abs, err := filepath.Abs(name); err == nil && name != "" && name != "-" {
+			// record the converted file where the run has a conversion
+			uri := (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+			for _, run := range log.Runs {
+				if run.Conversion != nil {
+					run.Conversion.AnalysisToolLogFiles = append(run.Conversion.AnalysisToolLogFiles, &sarif.ArtifactLocation{Uri: uri, Index: -1})
+				}
+			}
+		}
+		return writeLog(os.Stdout, log)
+	},
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceReader(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(root, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pkg", "x.go"), []byte("inside"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("outside"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	read := sourceReader(root)
+	tests := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{"relative", "pkg/x.go", "inside"},
+		{"relative escaping within the root", "pkg/../pkg/x.go", "inside"},
+		{"file uri under the root", "file://" + filepath.ToSlash(filepath.Join(root, "pkg", "x.go")), "inside"},
+		{"relative escaping the root", "../secret", ""},
+		{"escaped dots", "pkg/%2e%2e/%2e%2e/secret", ""},
+		{"file uri outside the root", "file://" + filepath.ToSlash(filepath.Join(dir, "secret")), ""},
+		{"file uri escaping the root", "file://" + filepath.ToSlash(root) + "/../secret", ""},
+		{"absolute path", filepath.ToSlash(filepath.Join(dir, "secret")), ""},
+		{"remote file uri", "file://example.com/etc/passwd", ""},
+		{"other scheme", "https://example.com/x.go", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := read(tt.uri)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("read(%q) = %q, want an error", tt.uri, b)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("read(%q) = %q, want %q", tt.uri, b, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package sarif
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// CodeClimateFingerprintKey is the key of Result.Fingerprints under which
+// ReadCodeClimate records the fingerprint of an issue, and from which
+// WriteCodeClimate takes it back.
+const CodeClimateFingerprintKey = "codeClimate/v1"
+
+// codeClimateSeverities maps effective levels onto Code Climate severities,
+// and back through levelsBySeverity.
+var codeClimateSeverities = map[string]string{
+	"error":   "critical",
+	"warning": "major",
+	"note":    "minor",
+	"none":    "info",
+}
+
+var levelsBySeverity = map[string]string{
+	"blocker":  "error",
+	"critical": "error",
+	"major":    "warning",
+	"minor":    "note",
+	"info":     "none",
+}
+
+// codeClimateCategories are the categories of the Code Climate specification.
+var codeClimateCategories = []string{
+	"Bug Risk", "Clarity", "Compatibility", "Complexity", "Duplication", "Performance", "Security", "Style",
+}
+
+type codeClimateIssue struct {
+	Type           string                 `json:"type"`
+	CheckName      string                 `json:"check_name"`
+	Description    string                 `json:"description"`
+	Content        *codeClimateContent    `json:"content,omitempty"`
+	Categories     []string               `json:"categories"`
+	Location       codeClimateLocation    `json:"location"`
+	OtherLocations []*codeClimateLocation `json:"other_locations,omitempty"`
+	Severity       string                 `json:"severity,omitempty"`
+	Fingerprint    string                 `json:"fingerprint,omitempty"`
+	EngineName     string                 `json:"engine_name,omitempty"`
+}
+
+type codeClimateContent struct {
+	Body string `json:"body"`
+}
+
+type codeClimateLocation struct {
+	Path      string                `json:"path"`
+	Lines     *codeClimateLines     `json:"lines,omitempty"`
+	Positions *codeClimatePositions `json:"positions,omitempty"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+type codeClimatePositions struct {
+	Begin codeClimatePosition `json:"begin"`
+	End   codeClimatePosition `json:"end"`
+}
+
+type codeClimatePosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// WriteCodeClimate writes the results of log to w as a JSON array of Code
+// Climate issues, the format of GitLab's Code Quality reports. Each result
+// becomes an issue whose check name is its rule id, whose severity follows
+// its effective level, and whose location is its first location in an
+// artifact; results without one are left out, as an issue needs a path.
+//
+// Paths are made relative to the repository root through the run's version
+// control provenance where possible. The fingerprint of an issue is the one
+// recorded under CodeClimateFingerprintKey, or else a hash of the rule id and
+// the result's partial fingerprints, or else a hash of the rule id, location
+// and message.
+func WriteCodeClimate(w io.Writer, log *SARIF) error {
+	issues := []*codeClimateIssue{}
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		engine := ""
+		if run.Tool != nil && run.Tool.Driver != nil {
+			engine = run.Tool.Driver.Name
+		}
+		for _, result := range run.Results {
+			if result == nil {
+				continue
+			}
+			var locations []*codeClimateLocation
+			for _, loc := range result.Locations {
+				if loc != nil && loc.PhysicalLocation != nil && loc.PhysicalLocation.ArtifactLocation != nil {
+					locations = append(locations, run.codeClimateLocation(loc.PhysicalLocation))
+				}
+			}
+			if len(locations) == 0 {
+				continue
+			}
+			msg, err := run.FormatMessage(result, false)
+			if err != nil {
+				msg = "(" + err.Error() + ")"
+			}
+			issue := &codeClimateIssue{
+				Type:           "issue",
+				CheckName:      withDefault(run.ruleID(result), engine),
+				Description:    strings.Join(strings.Fields(msg), " "),
+				Categories:     []string{"Bug Risk"},
+				Location:       *locations[0],
+				OtherLocations: locations[1:],
+				Severity:       codeClimateSeverities[run.EffectiveLevel(result)],
+				EngineName:     engine,
+			}
+			if rule := run.rule(result); rule != nil {
+				if rule.Help != nil {
+					if body := withDefault(rule.Help.Markdown, rule.Help.Text); body != "" {
+						issue.Content = &codeClimateContent{Body: body}
+					}
+				}
+				if rule.Properties != nil {
+					if categories := codeClimateCategoriesOf(rule.Properties.Tags); len(categories) > 0 {
+						issue.Categories = categories
+					}
+				}
+			}
+			issue.Fingerprint = codeClimateFingerprint(issue, result)
+			issues = append(issues, issue)
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+// codeClimateLocation converts pl, which has an artifact location.
+func (run *Run) codeClimateLocation(pl *PhysicalLocation) *codeClimateLocation {
+	loc := &codeClimateLocation{Path: run.reportPath(pl.ArtifactLocation)}
+	r := pl.Region
+	if r == nil || r.StartLine <= 0 {
+		// an issue always has a line
+		loc.Lines = &codeClimateLines{Begin: 1, End: 1}
+		return loc
+	}
+	end := withDefaultInt(r.EndLine, r.StartLine)
+	if r.StartColumn > 0 {
+		loc.Positions = &codeClimatePositions{
+			Begin: codeClimatePosition{Line: r.StartLine, Column: r.StartColumn},
+			End:   codeClimatePosition{Line: end, Column: r.EndColumn},
+		}
+	} else {
+		loc.Lines = &codeClimateLines{Begin: r.StartLine, End: end}
+	}
+	return loc
+}
+
+// reportPath returns the path of loc for reports that expect paths relative
+// to the repository root: relative through the run's version control
+// provenance where possible, as displayed by displayURI otherwise.
+func (run *Run) reportPath(loc *ArtifactLocation) string {
+	for _, vcs := range run.VersionControlProvenance {
+		if vcs == nil {
+			continue
+		}
+		if rel, ok := run.repositoryPath(vcs, loc); ok {
+			if p, err := url.PathUnescape(rel); err == nil {
+				return p
+			}
+		}
+	}
+	return strings.TrimPrefix(run.displayURI(loc), "./")
+}
+
+// codeClimateCategoriesOf returns the tags that name Code Climate categories,
+// regardless of case.
+func codeClimateCategoriesOf(tags []string) []string {
+	var categories []string
+	for _, c := range codeClimateCategories {
+		if containsFold(tags, c) {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+func codeClimateFingerprint(issue *codeClimateIssue, result *Result) string {
+	if fp := result.Fingerprints[CodeClimateFingerprintKey]; fp != "" {
+		return fp
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", issue.CheckName)
+	if len(result.PartialFingerprints) > 0 {
+		for _, k := range sortedKeys(result.PartialFingerprints) {
+			fmt.Fprintf(h, "%s=%s\x00", k, result.PartialFingerprints[k])
+		}
+	} else {
+		line := 0
+		if issue.Location.Lines != nil {
+			line = issue.Location.Lines.Begin
+		} else if issue.Location.Positions != nil {
+			line = issue.Location.Positions.Begin.Line
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%s", issue.Location.Path, line, issue.Description)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ReadCodeClimate converts the Code Climate issues read from r into a SARIF
+// log. It accepts a JSON array of issues, as in GitLab's Code Quality
+// reports, as well as the stream of issues separated by NUL characters or
+// whitespace that Code Climate engines write. Entries whose type is not
+// "issue" are ignored.
+//
+// The issues of each engine become a run with a rule per check name, named
+// after the engine for issues without a check name. The
+// categories of an issue become tags of its rule, its content becomes the
+// rule's help, and its fingerprint is kept under CodeClimateFingerprintKey.
+func ReadCodeClimate(r io.Reader) (*SARIF, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.ReplaceAll(data, []byte{0}, []byte{'\n'})
+	var issues []*codeClimateIssue
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := dec.Decode(&issues); err != nil {
+			return nil, fmt.Errorf("reading Code Climate issues: %w", err)
+		}
+	} else {
+		for dec.More() {
+			var issue codeClimateIssue
+			if err := dec.Decode(&issue); err != nil {
+				return nil, fmt.Errorf("reading Code Climate issues: %w", err)
+			}
+			issues = append(issues, &issue)
+		}
+	}
+
+	log := newImportedLog()
+	runs := make(map[string]*Run)
+	rules := make(map[string]map[string]int)
+	for i, issue := range issues {
+		if issue == nil || issue.Type != "" && !strings.EqualFold(issue.Type, "issue") {
+			continue
+		}
+		if issue.Location.Path == "" {
+			return nil, fmt.Errorf("Code Climate issue %d has no location path", i)
+		}
+		engine := withDefault(issue.EngineName, "codeclimate")
+		run := runs[engine]
+		if run == nil {
+			run = newImportedRun(engine)
+			runs[engine] = run
+			rules[engine] = make(map[string]int)
+			log.Runs = append(log.Runs, run)
+		}
+		// an issue without a check name is attributed to a rule named after
+		// its engine, as WriteCodeClimate names the check of a result
+		// without a rule
+		checkName := withDefault(issue.CheckName, engine)
+		ruleIndex := run.importedRule(rules[engine], checkName)
+		rule := run.Tool.Driver.Rules[ruleIndex]
+		for _, c := range issue.Categories {
+			if rule.Properties == nil {
+				rule.Properties = &PropertyBag{}
+			}
+			if !contains(rule.Properties.Tags, c) {
+				rule.Properties.Tags = append(rule.Properties.Tags, c)
+			}
+		}
+		if issue.Content != nil && issue.Content.Body != "" && rule.Help == nil {
+			rule.Help = &MultiformatMessageString{Text: issue.Content.Body, Markdown: issue.Content.Body}
+		}
+
+		result := &Result{
+			RuleId:    checkName,
+			RuleIndex: ruleIndex,
+			Level:     withDefault(levelsBySeverity[strings.ToLower(issue.Severity)], "warning"),
+			Message:   &Message{Text: issue.Description},
+			Locations: []*Location{issue.Location.location()},
+			Rank:      -1,
+		}
+		for _, other := range issue.OtherLocations {
+			if other != nil && other.Path != "" {
+				result.Locations = append(result.Locations, other.location())
+			}
+		}
+		if issue.Fingerprint != "" {
+			result.Fingerprints = map[string]string{CodeClimateFingerprintKey: issue.Fingerprint}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return log, nil
+}
+
+func (l *codeClimateLocation) location() *Location {
+	pl := &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: pathURI(l.Path), Index: -1}}
+	switch {
+	case l.Positions != nil && l.Positions.Begin.Line > 0:
+		pl.Region = &Region{
+			StartLine:   l.Positions.Begin.Line,
+			StartColumn: l.Positions.Begin.Column,
+			EndLine:     l.Positions.End.Line,
+			EndColumn:   l.Positions.End.Column,
+		}
+	case l.Lines != nil && l.Lines.Begin > 0:
+		pl.Region = &Region{StartLine: l.Lines.Begin, EndLine: l.Lines.End}
+	}
+	return &Location{PhysicalLocation: pl}
+}
+
+// pathURI returns the URI of a file path as reported by another tool: a file
+// URI if the path is absolute, a relative reference otherwise.
+func pathURI(path string) string {
+	if len(path) >= 3 && path[1] == ':' && (path[2] == '\\' || path[2] == '/') {
+		// a Windows path with a drive letter
+		path = "/" + strings.ReplaceAll(path, "\\", "/")
+	}
+	u := &url.URL{Path: path}
+	if strings.HasPrefix(path, "/") {
+		u.Scheme = "file"
+	}
+	return u.String()
+}
+
+// newImportedLog returns an empty log for results converted from another
+// format.
+func newImportedLog() *SARIF {
+	return &SARIF{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []*Run{},
+	}
+}
+
+// newImportedRun returns an empty run of the named tool.
+func newImportedRun(tool string) *Run {
+	return &Run{
+		Tool:    &Tool{Driver: &ToolComponent{Name: tool}},
+		Results: []*Result{},
+	}
+}
+
+// importedRule returns the index of the driver rule with the given id, adding
+// the rule if the run has none yet. rules maps the ids of the rules of run to
+// their indexes and is kept up to date.
+func (run *Run) importedRule(rules map[string]int, id string) int {
+	if i, ok := rules[id]; ok {
+		return i
+	}
+	run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &ReportingDescriptor{Id: id})
+	rules[id] = len(run.Tool.Driver.Rules) - 1
+	return rules[id]
+}
@@ -0,0 +1,93 @@
+package sarif
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteCodeClimate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCodeClimate(&buf, readTestLog(t, "sample.sarif")); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "codeclimate-export.json", buf.Bytes())
+}
+
+func TestReadCodeClimate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "codeclimate.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := ReadCodeClimate(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "codeclimate-import.sarif", marshalTestLog(t, log))
+
+	for _, run := range log.Runs {
+		for _, rule := range run.Tool.Driver.Rules {
+			if rule.Id == "" {
+				t.Errorf("run of %s has a rule without id", run.Tool.Driver.Name)
+			}
+		}
+	}
+}
+
+func TestReadCodeClimateStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		results int
+		wantErr bool
+	}{
+		{"empty", "", 0, false},
+		{"empty array", "[]", 0, false},
+		{"nul separated", `{"type":"issue","check_name":"a","description":"d","location":{"path":"x.go"}}` + "\x00" + `{"type":"issue","check_name":"b","description":"d","location":{"path":"y.go"}}`, 2, false},
+		{"whitespace separated", `{"check_name":"a","description":"d","location":{"path":"x.go"}}` + "\n" + `{"check_name":"a","description":"d","location":{"path":"y.go"}}`, 2, false},
+		{"no path", `[{"check_name":"a","description":"d","location":{}}]`, 0, true},
+		{"malformed", `[{"check_name":`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := ReadCodeClimate(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ReadCodeClimate() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := 0
+			for _, run := range log.Runs {
+				n += len(run.Results)
+			}
+			if n != tt.results {
+				t.Errorf("ReadCodeClimate() returned %d results, want %d", n, tt.results)
+			}
+		})
+	}
+}
+
+func TestPathURI(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"src/a.go", "src/a.go"},
+		{"./src/a.go", "./src/a.go"},
+		{"src/a b.go", "src/a%20b.go"},
+		{"/home/runner/a.go", "file:///home/runner/a.go"},
+		{`C:\work\a.go`, "file:///C:/work/a.go"},
+		{"C:/work/a.go", "file:///C:/work/a.go"},
+		{"a#1.go", "a%231.go"},
+	}
+	for _, tt := range tests {
+		if got := pathURI(tt.path); got != tt.want {
+			t.Errorf("pathURI(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DescriptorKind identifies which descriptors of a tool component a
+// ReportingDescriptorReference refers to.
+type DescriptorKind int
+
+const (
+	// RuleDescriptor refers to ToolComponent.Rules.
+	RuleDescriptor DescriptorKind = iota
+	// NotificationDescriptor refers to ToolComponent.Notifications.
+	NotificationDescriptor
+	// TaxonDescriptor refers to ToolComponent.Taxa.
+	TaxonDescriptor
+)
+
+var (
+	// ErrToolComponentNotFound is returned when a ToolComponentReference
+	// does not identify any tool component of the run.
+	ErrToolComponentNotFound = errors.New("tool component not found")
+
+	// ErrDescriptorNotFound is returned when a ReportingDescriptorReference
+	// does not identify any descriptor of its tool component.
+	ErrDescriptorNotFound = errors.New("reporting descriptor not found")
+)
+
+// RuleFor returns the rule that produced result and the tool component that
+// defines it. Result.Rule is followed into Tool.Extensions when it names a
+// tool component; otherwise the rule is looked up among the rules of
+// Tool.Driver by index, guid or id, in that order. An index is only trusted
+// when it agrees with the id and guid the reference carries. A hierarchical
+// rule id such as "CA2000/sub" that has no rule of its own resolves to its
+// closest ancestor, here "CA2000".
+//
+// A result with neither a rule id nor Result.Rule names no rule, and RuleFor
+// returns nil and no error for it: its RuleIndex alone is not trusted, since
+// an absent index decodes as 0.
+func (run *Run) RuleFor(result *Result) (*ReportingDescriptor, *ToolComponent, error) {
+	if result.RuleId == "" && result.Rule == nil {
+		return nil, nil, nil
+	}
+	ref := &ReportingDescriptorReference{Id: result.RuleId, Index: result.RuleIndex}
+	if r := result.Rule; r != nil {
+		ref = &ReportingDescriptorReference{Guid: r.Guid, Id: r.Id, Index: r.Index, ToolComponent: r.ToolComponent}
+		if ref.Id == "" {
+			ref.Id = result.RuleId
+		}
+	}
+	return run.ResolveDescriptor(ref, RuleDescriptor)
+}
+
+// ResolveDescriptor returns the descriptor that ref refers to, and the tool
+// component that defines it. kind states whether ref refers to a rule, as
+// Notification.AssociatedRule does, to a notification, as
+// Notification.Descriptor does, or to a taxon, as Result.Taxa do.
+//
+// ref.ToolComponent is resolved by guid, index or name. Its index refers to
+// Tool.Extensions for rules and notifications and to Run.Taxonomies for taxa.
+// A reference without a tool component refers to the driver, except that a
+// taxon is then looked up in every taxonomy of the run.
+func (run *Run) ResolveDescriptor(ref *ReportingDescriptorReference, kind DescriptorKind) (*ReportingDescriptor, *ToolComponent, error) {
+	if ref == nil {
+		return nil, nil, errors.New("reporting descriptor reference is nil")
+	}
+	if ref.ToolComponent == nil && kind == TaxonDescriptor {
+		for _, tc := range run.Taxonomies {
+			if d := findDescriptor(descriptors(tc, kind), ref, false); d != nil {
+				return d, tc, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("%w: taxon %s", ErrDescriptorNotFound, describeRef(ref))
+	}
+	tc, err := run.resolveToolComponent(ref.ToolComponent, kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d := findDescriptor(descriptors(tc, kind), ref, true); d != nil {
+		return d, tc, nil
+	}
+	return nil, tc, fmt.Errorf("%w: %s in %q", ErrDescriptorNotFound, describeRef(ref), tc.Name)
+}
+
+// resolveToolComponent returns the tool component ref refers to; a nil ref
+// refers to the driver.
+func (run *Run) resolveToolComponent(ref *ToolComponentReference, kind DescriptorKind) (*ToolComponent, error) {
+	var driver *ToolComponent
+	var extensions []*ToolComponent
+	if run.Tool != nil {
+		driver = run.Tool.Driver
+		extensions = run.Tool.Extensions
+	}
+	if ref == nil {
+		if driver == nil {
+			return nil, fmt.Errorf("%w: run has no driver", ErrToolComponentNotFound)
+		}
+		return driver, nil
+	}
+	indexed := extensions
+	if kind == TaxonDescriptor {
+		indexed = run.Taxonomies
+	}
+	all := append(append([]*ToolComponent{driver}, extensions...), run.Taxonomies...)
+	matches := func(tc *ToolComponent) bool {
+		return tc != nil && (ref.Guid == "" || strings.EqualFold(tc.Guid, ref.Guid)) && (ref.Name == "" || tc.Name == ref.Name)
+	}
+	if ref.Index >= 0 && ref.Index < len(indexed) && matches(indexed[ref.Index]) {
+		return indexed[ref.Index], nil
+	}
+	if ref.Guid != "" || ref.Name != "" {
+		for _, tc := range all {
+			if matches(tc) {
+				return tc, nil
+			}
+		}
+	}
+	name := ref.Name
+	if name == "" {
+		name = ref.Guid
+	}
+	if name == "" {
+		name = fmt.Sprintf("#%d", ref.Index)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrToolComponentNotFound, name)
+}
+
+func descriptors(tc *ToolComponent, kind DescriptorKind) []*ReportingDescriptor {
+	if tc == nil {
+		return nil
+	}
+	switch kind {
+	case NotificationDescriptor:
+		return tc.Notifications
+	case TaxonDescriptor:
+		return tc.Taxa
+	}
+	return tc.Rules
+}
+
+// findDescriptor looks ref up in ds by index, guid, id, deprecated id and
+// finally by the ancestors of a hierarchical id. The index is only used if
+// useIndex is set and it agrees with the id and guid of ref.
+func findDescriptor(ds []*ReportingDescriptor, ref *ReportingDescriptorReference, useIndex bool) *ReportingDescriptor {
+	if i := ref.Index; useIndex && i >= 0 && i < len(ds) && ds[i] != nil &&
+		(ref.Id == "" || ds[i].Id == ref.Id) && (ref.Guid == "" || strings.EqualFold(ds[i].Guid, ref.Guid)) {
+		return ds[i]
+	}
+	if ref.Guid != "" {
+		for _, d := range ds {
+			if d != nil && (strings.EqualFold(d.Guid, ref.Guid) || containsFold(d.DeprecatedGuids, ref.Guid)) {
+				return d
+			}
+		}
+	}
+	if ref.Id == "" {
+		return nil
+	}
+	for _, d := range ds {
+		if d != nil && d.Id == ref.Id {
+			return d
+		}
+	}
+	for _, d := range ds {
+		if d != nil && contains(d.DeprecatedIds, ref.Id) {
+			return d
+		}
+	}
+	for id := ref.Id; strings.Contains(id, "/"); {
+		id = id[:strings.LastIndex(id, "/")]
+		for _, d := range ds {
+			if d != nil && d.Id == id {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+func describeRef(ref *ReportingDescriptorReference) string {
+	switch {
+	case ref.Id != "":
+		return fmt.Sprintf("%q", ref.Id)
+	case ref.Guid != "":
+		return ref.Guid
+	}
+	return fmt.Sprintf("#%d", ref.Index)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
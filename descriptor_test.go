@@ -0,0 +1,92 @@
+package sarif
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuleFor(t *testing.T) {
+	run := &Run{
+		Tool: &Tool{
+			Driver: &ToolComponent{Name: "driver", Rules: []*ReportingDescriptor{
+				{Id: "CA2000"},
+				{Id: "CA3000", Guid: "6f0f0b5e-0000-4000-8000-000000000001", DeprecatedIds: []string{"OLD1"}},
+			}},
+			Extensions: []*ToolComponent{
+				{Name: "ext", Guid: "6f0f0b5e-0000-4000-8000-0000000000aa", Rules: []*ReportingDescriptor{{Id: "X1"}}},
+			},
+		},
+	}
+	driver, ext := run.Tool.Driver, run.Tool.Extensions[0]
+	tests := []struct {
+		name      string
+		result    *Result
+		want      *ReportingDescriptor
+		component *ToolComponent
+		wantErr   error
+	}{
+		{"by reference index", &Result{Rule: &ReportingDescriptorReference{Index: 1}}, driver.Rules[1], driver, nil},
+		{"index alone", &Result{RuleIndex: 1}, nil, nil, nil},
+		{"absent index", &Result{}, nil, nil, nil},
+		{"by id", &Result{RuleId: "CA3000", RuleIndex: -1}, driver.Rules[1], driver, nil},
+		{"index disagreeing with id", &Result{RuleId: "CA3000", RuleIndex: 0}, driver.Rules[1], driver, nil},
+		{"by guid", &Result{Rule: &ReportingDescriptorReference{Guid: "6F0F0B5E-0000-4000-8000-000000000001", Index: -1}}, driver.Rules[1], driver, nil},
+		{"by deprecated id", &Result{RuleId: "OLD1", RuleIndex: -1}, driver.Rules[1], driver, nil},
+		{"hierarchical id", &Result{RuleId: "CA2000/sub/leaf", RuleIndex: -1}, driver.Rules[0], driver, nil},
+		{
+			"extension by index",
+			&Result{Rule: &ReportingDescriptorReference{Id: "X1", Index: 0, ToolComponent: &ToolComponentReference{Index: 0}}},
+			ext.Rules[0], ext, nil,
+		},
+		{
+			"extension by name",
+			&Result{Rule: &ReportingDescriptorReference{Id: "X1", Index: -1, ToolComponent: &ToolComponentReference{Name: "ext", Index: -1}}},
+			ext.Rules[0], ext, nil,
+		},
+		{"unknown id", &Result{RuleId: "nope", RuleIndex: -1}, nil, driver, ErrDescriptorNotFound},
+		{
+			"unknown component",
+			&Result{Rule: &ReportingDescriptorReference{Id: "X1", Index: -1, ToolComponent: &ToolComponentReference{Name: "nope", Index: -1}}},
+			nil, nil, ErrToolComponentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, tc, err := run.RuleFor(tt.result)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RuleFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if rule != tt.want || tc != tt.component {
+				t.Errorf("RuleFor() = %+v in %+v, want %+v in %+v", rule, tc, tt.want, tt.component)
+			}
+		})
+	}
+}
+
+func TestResolveTaxon(t *testing.T) {
+	cwe := &ToolComponent{Name: "CWE", Taxa: []*ReportingDescriptor{{Id: "79"}, {Id: "89"}}}
+	owasp := &ToolComponent{Name: "OWASP", Taxa: []*ReportingDescriptor{{Id: "A03"}}}
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t"}}, Taxonomies: []*ToolComponent{cwe, owasp}}
+	tests := []struct {
+		name    string
+		ref     *ReportingDescriptorReference
+		want    *ReportingDescriptor
+		wantErr error
+	}{
+		{"any taxonomy", &ReportingDescriptorReference{Id: "A03", Index: -1}, owasp.Taxa[0], nil},
+		{"taxonomy by index", &ReportingDescriptorReference{Id: "89", Index: 1, ToolComponent: &ToolComponentReference{Index: 0}}, cwe.Taxa[1], nil},
+		{"taxonomy by name", &ReportingDescriptorReference{Id: "79", Index: -1, ToolComponent: &ToolComponentReference{Name: "CWE", Index: -1}}, cwe.Taxa[0], nil},
+		{"unknown taxon", &ReportingDescriptorReference{Id: "1", Index: -1}, nil, ErrDescriptorNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _, err := run.ResolveDescriptor(tt.ref, TaxonDescriptor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveDescriptor() error = %v, want %v", err, tt.wantErr)
+			}
+			if d != tt.want {
+				t.Errorf("ResolveDescriptor() = %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Diff compares two SARIF objects of the same type and returns the JSON
+// pointers of the properties in which they differ, or nil if they are
+// semantically equal. The comparison ignores the differences that do not
+// survive a round trip through JSON or that the spec assigns no meaning to:
+// nil and empty slices and maps are equal, a nil object equals one with all
+// properties unset, an unset property equals its spec-defined default (such as
+// a result kind of "fail"), property bag tags are compared as a set and
+// property bag values are compared by their JSON encoding. Objects of
+// different types differ at the root pointer "".
+func Diff(a, b any) []string {
+	d := &differ{}
+	d.diffAny(a, b, "")
+	return d.paths
+}
+
+// differ accumulates the pointers at which two objects differ, stopping after
+// limit differences if limit is positive.
+type differ struct {
+	paths []string
+	limit int
+}
+
+func (d *differ) done() bool {
+	return d.limit > 0 && len(d.paths) >= d.limit
+}
+
+func (d *differ) report(ptr string) {
+	if !d.done() {
+		d.paths = append(d.paths, ptr)
+	}
+}
+
+func diffValue[T comparable](d *differ, a, b T, ptr string) {
+	if a != b {
+		d.report(ptr)
+	}
+}
+
+func withDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func (d *differ) diffStrings(a, b []string, ptr string) {
+	if len(a) != len(b) {
+		d.report(ptr)
+		return
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			d.report(ptr)
+			return
+		}
+	}
+}
+
+func (d *differ) diffStringMap(a, b map[string]string, ptr string) {
+	for _, k := range unionKeys(a, b) {
+		av, aok := a[k]
+		bv, bok := b[k]
+		if aok != bok || av != bv {
+			d.report(ptr + "/" + escapePointer(k))
+		}
+	}
+}
+
+func (d *differ) diffPropertyBag(a, b *PropertyBag, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &PropertyBag{}
+	}
+	if b == nil {
+		b = &PropertyBag{}
+	}
+	if !sameSet(a.Tags, b.Tags) {
+		d.report(ptr + "/tags")
+	}
+	for _, k := range unionKeys(a.AdditionalProperties, b.AdditionalProperties) {
+		av, aok := a.AdditionalProperties[k]
+		bv, bok := b.AdditionalProperties[k]
+		if aok != bok || !sameJSON(av, bv) {
+			d.report(ptr + "/" + escapePointer(k))
+		}
+	}
+}
+
+func sameSet(a, b []string) bool {
+	set := make(map[string]int)
+	for _, s := range a {
+		set[s] |= 1
+	}
+	for _, s := range b {
+		set[s] |= 2
+	}
+	for _, v := range set {
+		if v != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameJSON reports whether a and b have the same JSON encoding, which makes
+// e.g. int(1) and float64(1) compare equal.
+func sameJSON(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && bytes.Equal(aj, bj)
+}
+
+// unionKeys returns the keys of a and b in sorted order.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
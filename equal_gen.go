@@ -0,0 +1,2316 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package sarif
+
+import (
+	"strconv"
+)
+
+// diffAny dispatches to the diff method for the dynamic type of a.
+func (d *differ) diffAny(a, b any, ptr string) {
+	switch a := a.(type) {
+	case *Address:
+		if b, ok := b.(*Address); ok {
+			d.diffAddress(a, b, ptr)
+			return
+		}
+	case *Artifact:
+		if b, ok := b.(*Artifact); ok {
+			d.diffArtifact(a, b, ptr)
+			return
+		}
+	case *ArtifactChange:
+		if b, ok := b.(*ArtifactChange); ok {
+			d.diffArtifactChange(a, b, ptr)
+			return
+		}
+	case *ArtifactContent:
+		if b, ok := b.(*ArtifactContent); ok {
+			d.diffArtifactContent(a, b, ptr)
+			return
+		}
+	case *ArtifactLocation:
+		if b, ok := b.(*ArtifactLocation); ok {
+			d.diffArtifactLocation(a, b, ptr)
+			return
+		}
+	case *Attachment:
+		if b, ok := b.(*Attachment); ok {
+			d.diffAttachment(a, b, ptr)
+			return
+		}
+	case *CodeFlow:
+		if b, ok := b.(*CodeFlow); ok {
+			d.diffCodeFlow(a, b, ptr)
+			return
+		}
+	case *ConfigurationOverride:
+		if b, ok := b.(*ConfigurationOverride); ok {
+			d.diffConfigurationOverride(a, b, ptr)
+			return
+		}
+	case *Conversion:
+		if b, ok := b.(*Conversion); ok {
+			d.diffConversion(a, b, ptr)
+			return
+		}
+	case *Edge:
+		if b, ok := b.(*Edge); ok {
+			d.diffEdge(a, b, ptr)
+			return
+		}
+	case *EdgeTraversal:
+		if b, ok := b.(*EdgeTraversal); ok {
+			d.diffEdgeTraversal(a, b, ptr)
+			return
+		}
+	case *Exception:
+		if b, ok := b.(*Exception); ok {
+			d.diffException(a, b, ptr)
+			return
+		}
+	case *ExternalProperties:
+		if b, ok := b.(*ExternalProperties); ok {
+			d.diffExternalProperties(a, b, ptr)
+			return
+		}
+	case *ExternalPropertyFileReference:
+		if b, ok := b.(*ExternalPropertyFileReference); ok {
+			d.diffExternalPropertyFileReference(a, b, ptr)
+			return
+		}
+	case *ExternalPropertyFileReferences:
+		if b, ok := b.(*ExternalPropertyFileReferences); ok {
+			d.diffExternalPropertyFileReferences(a, b, ptr)
+			return
+		}
+	case *Fix:
+		if b, ok := b.(*Fix); ok {
+			d.diffFix(a, b, ptr)
+			return
+		}
+	case *Graph:
+		if b, ok := b.(*Graph); ok {
+			d.diffGraph(a, b, ptr)
+			return
+		}
+	case *GraphTraversal:
+		if b, ok := b.(*GraphTraversal); ok {
+			d.diffGraphTraversal(a, b, ptr)
+			return
+		}
+	case *Invocation:
+		if b, ok := b.(*Invocation); ok {
+			d.diffInvocation(a, b, ptr)
+			return
+		}
+	case *Location:
+		if b, ok := b.(*Location); ok {
+			d.diffLocation(a, b, ptr)
+			return
+		}
+	case *LocationRelationship:
+		if b, ok := b.(*LocationRelationship); ok {
+			d.diffLocationRelationship(a, b, ptr)
+			return
+		}
+	case *LogicalLocation:
+		if b, ok := b.(*LogicalLocation); ok {
+			d.diffLogicalLocation(a, b, ptr)
+			return
+		}
+	case *Message:
+		if b, ok := b.(*Message); ok {
+			d.diffMessage(a, b, ptr)
+			return
+		}
+	case *MultiformatMessageString:
+		if b, ok := b.(*MultiformatMessageString); ok {
+			d.diffMultiformatMessageString(a, b, ptr)
+			return
+		}
+	case *Node:
+		if b, ok := b.(*Node); ok {
+			d.diffNode(a, b, ptr)
+			return
+		}
+	case *Notification:
+		if b, ok := b.(*Notification); ok {
+			d.diffNotification(a, b, ptr)
+			return
+		}
+	case *PhysicalLocation:
+		if b, ok := b.(*PhysicalLocation); ok {
+			d.diffPhysicalLocation(a, b, ptr)
+			return
+		}
+	case *PropertyBag:
+		if b, ok := b.(*PropertyBag); ok {
+			d.diffPropertyBag(a, b, ptr)
+			return
+		}
+	case *Rectangle:
+		if b, ok := b.(*Rectangle); ok {
+			d.diffRectangle(a, b, ptr)
+			return
+		}
+	case *Region:
+		if b, ok := b.(*Region); ok {
+			d.diffRegion(a, b, ptr)
+			return
+		}
+	case *Replacement:
+		if b, ok := b.(*Replacement); ok {
+			d.diffReplacement(a, b, ptr)
+			return
+		}
+	case *ReportingConfiguration:
+		if b, ok := b.(*ReportingConfiguration); ok {
+			d.diffReportingConfiguration(a, b, ptr)
+			return
+		}
+	case *ReportingDescriptor:
+		if b, ok := b.(*ReportingDescriptor); ok {
+			d.diffReportingDescriptor(a, b, ptr)
+			return
+		}
+	case *ReportingDescriptorReference:
+		if b, ok := b.(*ReportingDescriptorReference); ok {
+			d.diffReportingDescriptorReference(a, b, ptr)
+			return
+		}
+	case *ReportingDescriptorRelationship:
+		if b, ok := b.(*ReportingDescriptorRelationship); ok {
+			d.diffReportingDescriptorRelationship(a, b, ptr)
+			return
+		}
+	case *Result:
+		if b, ok := b.(*Result); ok {
+			d.diffResult(a, b, ptr)
+			return
+		}
+	case *ResultProvenance:
+		if b, ok := b.(*ResultProvenance); ok {
+			d.diffResultProvenance(a, b, ptr)
+			return
+		}
+	case *Run:
+		if b, ok := b.(*Run); ok {
+			d.diffRun(a, b, ptr)
+			return
+		}
+	case *RunAutomationDetails:
+		if b, ok := b.(*RunAutomationDetails); ok {
+			d.diffRunAutomationDetails(a, b, ptr)
+			return
+		}
+	case *SARIF:
+		if b, ok := b.(*SARIF); ok {
+			d.diffSARIF(a, b, ptr)
+			return
+		}
+	case *SpecialLocations:
+		if b, ok := b.(*SpecialLocations); ok {
+			d.diffSpecialLocations(a, b, ptr)
+			return
+		}
+	case *Stack:
+		if b, ok := b.(*Stack); ok {
+			d.diffStack(a, b, ptr)
+			return
+		}
+	case *StackFrame:
+		if b, ok := b.(*StackFrame); ok {
+			d.diffStackFrame(a, b, ptr)
+			return
+		}
+	case *Suppression:
+		if b, ok := b.(*Suppression); ok {
+			d.diffSuppression(a, b, ptr)
+			return
+		}
+	case *ThreadFlow:
+		if b, ok := b.(*ThreadFlow); ok {
+			d.diffThreadFlow(a, b, ptr)
+			return
+		}
+	case *ThreadFlowLocation:
+		if b, ok := b.(*ThreadFlowLocation); ok {
+			d.diffThreadFlowLocation(a, b, ptr)
+			return
+		}
+	case *Tool:
+		if b, ok := b.(*Tool); ok {
+			d.diffTool(a, b, ptr)
+			return
+		}
+	case *ToolComponent:
+		if b, ok := b.(*ToolComponent); ok {
+			d.diffToolComponent(a, b, ptr)
+			return
+		}
+	case *ToolComponentReference:
+		if b, ok := b.(*ToolComponentReference); ok {
+			d.diffToolComponentReference(a, b, ptr)
+			return
+		}
+	case *TranslationMetadata:
+		if b, ok := b.(*TranslationMetadata); ok {
+			d.diffTranslationMetadata(a, b, ptr)
+			return
+		}
+	case *VersionControlDetails:
+		if b, ok := b.(*VersionControlDetails); ok {
+			d.diffVersionControlDetails(a, b, ptr)
+			return
+		}
+	case *WebRequest:
+		if b, ok := b.(*WebRequest); ok {
+			d.diffWebRequest(a, b, ptr)
+			return
+		}
+	case *WebResponse:
+		if b, ok := b.(*WebResponse); ok {
+			d.diffWebResponse(a, b, ptr)
+			return
+		}
+	}
+	d.report(ptr)
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Address) Equal(other *Address) bool {
+	d := &differ{limit: 1}
+	d.diffAddress(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffAddress(a, b *Address, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Address{}
+	}
+	if b == nil {
+		b = &Address{}
+	}
+	diffValue(d, a.AbsoluteAddress, b.AbsoluteAddress, ptr+"/absoluteAddress")
+	diffValue(d, a.FullyQualifiedName, b.FullyQualifiedName, ptr+"/fullyQualifiedName")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	diffValue(d, a.Kind, b.Kind, ptr+"/kind")
+	diffValue(d, a.Length, b.Length, ptr+"/length")
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	diffValue(d, a.OffsetFromParent, b.OffsetFromParent, ptr+"/offsetFromParent")
+	diffValue(d, a.ParentIndex, b.ParentIndex, ptr+"/parentIndex")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.RelativeAddress, b.RelativeAddress, ptr+"/relativeAddress")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Artifact) Equal(other *Artifact) bool {
+	d := &differ{limit: 1}
+	d.diffArtifact(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffArtifact(a, b *Artifact, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Artifact{}
+	}
+	if b == nil {
+		b = &Artifact{}
+	}
+	d.diffArtifactContent(a.Contents, b.Contents, ptr+"/contents")
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	diffValue(d, a.Encoding, b.Encoding, ptr+"/encoding")
+	d.diffStringMap(a.Hashes, b.Hashes, ptr+"/hashes")
+	diffValue(d, a.LastModifiedTimeUtc, b.LastModifiedTimeUtc, ptr+"/lastModifiedTimeUtc")
+	diffValue(d, a.Length, b.Length, ptr+"/length")
+	d.diffArtifactLocation(a.Location, b.Location, ptr+"/location")
+	diffValue(d, a.MimeType, b.MimeType, ptr+"/mimeType")
+	diffValue(d, a.Offset, b.Offset, ptr+"/offset")
+	diffValue(d, a.ParentIndex, b.ParentIndex, ptr+"/parentIndex")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffStrings(a.Roles, b.Roles, ptr+"/roles")
+	diffValue(d, a.SourceLanguage, b.SourceLanguage, ptr+"/sourceLanguage")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ArtifactChange) Equal(other *ArtifactChange) bool {
+	d := &differ{limit: 1}
+	d.diffArtifactChange(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffArtifactChange(a, b *ArtifactChange, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ArtifactChange{}
+	}
+	if b == nil {
+		b = &ArtifactChange{}
+	}
+	d.diffArtifactLocation(a.ArtifactLocation, b.ArtifactLocation, ptr+"/artifactLocation")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Replacements) || i < len(b.Replacements); i++ {
+		if i >= len(a.Replacements) || i >= len(b.Replacements) {
+			d.report(ptr + "/replacements" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReplacement(a.Replacements[i], b.Replacements[i], ptr+"/replacements"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ArtifactContent) Equal(other *ArtifactContent) bool {
+	d := &differ{limit: 1}
+	d.diffArtifactContent(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffArtifactContent(a, b *ArtifactContent, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ArtifactContent{}
+	}
+	if b == nil {
+		b = &ArtifactContent{}
+	}
+	diffValue(d, a.Binary, b.Binary, ptr+"/binary")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffMultiformatMessageString(a.Rendered, b.Rendered, ptr+"/rendered")
+	diffValue(d, a.Text, b.Text, ptr+"/text")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ArtifactLocation) Equal(other *ArtifactLocation) bool {
+	d := &differ{limit: 1}
+	d.diffArtifactLocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffArtifactLocation(a, b *ArtifactLocation, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ArtifactLocation{}
+	}
+	if b == nil {
+		b = &ArtifactLocation{}
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Uri, b.Uri, ptr+"/uri")
+	diffValue(d, a.UriBaseId, b.UriBaseId, ptr+"/uriBaseId")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Attachment) Equal(other *Attachment) bool {
+	d := &differ{limit: 1}
+	d.diffAttachment(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffAttachment(a, b *Attachment, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Attachment{}
+	}
+	if b == nil {
+		b = &Attachment{}
+	}
+	d.diffArtifactLocation(a.ArtifactLocation, b.ArtifactLocation, ptr+"/artifactLocation")
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Rectangles) || i < len(b.Rectangles); i++ {
+		if i >= len(a.Rectangles) || i >= len(b.Rectangles) {
+			d.report(ptr + "/rectangles" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffRectangle(a.Rectangles[i], b.Rectangles[i], ptr+"/rectangles"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Regions) || i < len(b.Regions); i++ {
+		if i >= len(a.Regions) || i >= len(b.Regions) {
+			d.report(ptr + "/regions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffRegion(a.Regions[i], b.Regions[i], ptr+"/regions"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *CodeFlow) Equal(other *CodeFlow) bool {
+	d := &differ{limit: 1}
+	d.diffCodeFlow(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffCodeFlow(a, b *CodeFlow, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &CodeFlow{}
+	}
+	if b == nil {
+		b = &CodeFlow{}
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.ThreadFlows) || i < len(b.ThreadFlows); i++ {
+		if i >= len(a.ThreadFlows) || i >= len(b.ThreadFlows) {
+			d.report(ptr + "/threadFlows" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffThreadFlow(a.ThreadFlows[i], b.ThreadFlows[i], ptr+"/threadFlows"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ConfigurationOverride) Equal(other *ConfigurationOverride) bool {
+	d := &differ{limit: 1}
+	d.diffConfigurationOverride(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffConfigurationOverride(a, b *ConfigurationOverride, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ConfigurationOverride{}
+	}
+	if b == nil {
+		b = &ConfigurationOverride{}
+	}
+	d.diffReportingConfiguration(a.Configuration, b.Configuration, ptr+"/configuration")
+	d.diffReportingDescriptorReference(a.Descriptor, b.Descriptor, ptr+"/descriptor")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Conversion) Equal(other *Conversion) bool {
+	d := &differ{limit: 1}
+	d.diffConversion(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffConversion(a, b *Conversion, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Conversion{}
+	}
+	if b == nil {
+		b = &Conversion{}
+	}
+	for i := 0; i < len(a.AnalysisToolLogFiles) || i < len(b.AnalysisToolLogFiles); i++ {
+		if i >= len(a.AnalysisToolLogFiles) || i >= len(b.AnalysisToolLogFiles) {
+			d.report(ptr + "/analysisToolLogFiles" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifactLocation(a.AnalysisToolLogFiles[i], b.AnalysisToolLogFiles[i], ptr+"/analysisToolLogFiles"+"/"+strconv.Itoa(i))
+	}
+	d.diffInvocation(a.Invocation, b.Invocation, ptr+"/invocation")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffTool(a.Tool, b.Tool, ptr+"/tool")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Edge) Equal(other *Edge) bool {
+	d := &differ{limit: 1}
+	d.diffEdge(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffEdge(a, b *Edge, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Edge{}
+	}
+	if b == nil {
+		b = &Edge{}
+	}
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	d.diffMessage(a.Label, b.Label, ptr+"/label")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.SourceNodeId, b.SourceNodeId, ptr+"/sourceNodeId")
+	diffValue(d, a.TargetNodeId, b.TargetNodeId, ptr+"/targetNodeId")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *EdgeTraversal) Equal(other *EdgeTraversal) bool {
+	d := &differ{limit: 1}
+	d.diffEdgeTraversal(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffEdgeTraversal(a, b *EdgeTraversal, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &EdgeTraversal{}
+	}
+	if b == nil {
+		b = &EdgeTraversal{}
+	}
+	diffValue(d, a.EdgeId, b.EdgeId, ptr+"/edgeId")
+	for _, k := range unionKeys(a.FinalState, b.FinalState) {
+		av, aok := a.FinalState[k]
+		bv, bok := b.FinalState[k]
+		if aok != bok {
+			d.report(ptr + "/finalState" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/finalState"+"/"+escapePointer(k))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.StepOverEdgeCount, b.StepOverEdgeCount, ptr+"/stepOverEdgeCount")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Exception) Equal(other *Exception) bool {
+	d := &differ{limit: 1}
+	d.diffException(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffException(a, b *Exception, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Exception{}
+	}
+	if b == nil {
+		b = &Exception{}
+	}
+	for i := 0; i < len(a.InnerExceptions) || i < len(b.InnerExceptions); i++ {
+		if i >= len(a.InnerExceptions) || i >= len(b.InnerExceptions) {
+			d.report(ptr + "/innerExceptions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffException(a.InnerExceptions[i], b.InnerExceptions[i], ptr+"/innerExceptions"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Kind, b.Kind, ptr+"/kind")
+	diffValue(d, a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffStack(a.Stack, b.Stack, ptr+"/stack")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ExternalProperties) Equal(other *ExternalProperties) bool {
+	d := &differ{limit: 1}
+	d.diffExternalProperties(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffExternalProperties(a, b *ExternalProperties, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ExternalProperties{}
+	}
+	if b == nil {
+		b = &ExternalProperties{}
+	}
+	for i := 0; i < len(a.Addresses) || i < len(b.Addresses); i++ {
+		if i >= len(a.Addresses) || i >= len(b.Addresses) {
+			d.report(ptr + "/addresses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffAddress(a.Addresses[i], b.Addresses[i], ptr+"/addresses"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Artifacts) || i < len(b.Artifacts); i++ {
+		if i >= len(a.Artifacts) || i >= len(b.Artifacts) {
+			d.report(ptr + "/artifacts" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifact(a.Artifacts[i], b.Artifacts[i], ptr+"/artifacts"+"/"+strconv.Itoa(i))
+	}
+	d.diffConversion(a.Conversion, b.Conversion, ptr+"/conversion")
+	d.diffToolComponent(a.Driver, b.Driver, ptr+"/driver")
+	for i := 0; i < len(a.Extensions) || i < len(b.Extensions); i++ {
+		if i >= len(a.Extensions) || i >= len(b.Extensions) {
+			d.report(ptr + "/extensions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Extensions[i], b.Extensions[i], ptr+"/extensions"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.ExternalizedProperties, b.ExternalizedProperties, ptr+"/externalizedProperties")
+	for i := 0; i < len(a.Graphs) || i < len(b.Graphs); i++ {
+		if i >= len(a.Graphs) || i >= len(b.Graphs) {
+			d.report(ptr + "/graphs" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffGraph(a.Graphs[i], b.Graphs[i], ptr+"/graphs"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	for i := 0; i < len(a.Invocations) || i < len(b.Invocations); i++ {
+		if i >= len(a.Invocations) || i >= len(b.Invocations) {
+			d.report(ptr + "/invocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffInvocation(a.Invocations[i], b.Invocations[i], ptr+"/invocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.LogicalLocations) || i < len(b.LogicalLocations); i++ {
+		if i >= len(a.LogicalLocations) || i >= len(b.LogicalLocations) {
+			d.report(ptr + "/logicalLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLogicalLocation(a.LogicalLocations[i], b.LogicalLocations[i], ptr+"/logicalLocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Policies) || i < len(b.Policies); i++ {
+		if i >= len(a.Policies) || i >= len(b.Policies) {
+			d.report(ptr + "/policies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Policies[i], b.Policies[i], ptr+"/policies"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Results) || i < len(b.Results); i++ {
+		if i >= len(a.Results) || i >= len(b.Results) {
+			d.report(ptr + "/results" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffResult(a.Results[i], b.Results[i], ptr+"/results"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.RunGuid, b.RunGuid, ptr+"/runGuid")
+	diffValue(d, a.Schema, b.Schema, ptr+"/schema")
+	for i := 0; i < len(a.Taxonomies) || i < len(b.Taxonomies); i++ {
+		if i >= len(a.Taxonomies) || i >= len(b.Taxonomies) {
+			d.report(ptr + "/taxonomies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Taxonomies[i], b.Taxonomies[i], ptr+"/taxonomies"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.ThreadFlowLocations) || i < len(b.ThreadFlowLocations); i++ {
+		if i >= len(a.ThreadFlowLocations) || i >= len(b.ThreadFlowLocations) {
+			d.report(ptr + "/threadFlowLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffThreadFlowLocation(a.ThreadFlowLocations[i], b.ThreadFlowLocations[i], ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Translations) || i < len(b.Translations); i++ {
+		if i >= len(a.Translations) || i >= len(b.Translations) {
+			d.report(ptr + "/translations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Translations[i], b.Translations[i], ptr+"/translations"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Version, b.Version, ptr+"/version")
+	for i := 0; i < len(a.WebRequests) || i < len(b.WebRequests); i++ {
+		if i >= len(a.WebRequests) || i >= len(b.WebRequests) {
+			d.report(ptr + "/webRequests" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffWebRequest(a.WebRequests[i], b.WebRequests[i], ptr+"/webRequests"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.WebResponses) || i < len(b.WebResponses); i++ {
+		if i >= len(a.WebResponses) || i >= len(b.WebResponses) {
+			d.report(ptr + "/webResponses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffWebResponse(a.WebResponses[i], b.WebResponses[i], ptr+"/webResponses"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ExternalPropertyFileReference) Equal(other *ExternalPropertyFileReference) bool {
+	d := &differ{limit: 1}
+	d.diffExternalPropertyFileReference(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffExternalPropertyFileReference(a, b *ExternalPropertyFileReference, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ExternalPropertyFileReference{}
+	}
+	if b == nil {
+		b = &ExternalPropertyFileReference{}
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.ItemCount, b.ItemCount, ptr+"/itemCount")
+	d.diffArtifactLocation(a.Location, b.Location, ptr+"/location")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ExternalPropertyFileReferences) Equal(other *ExternalPropertyFileReferences) bool {
+	d := &differ{limit: 1}
+	d.diffExternalPropertyFileReferences(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffExternalPropertyFileReferences(a, b *ExternalPropertyFileReferences, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ExternalPropertyFileReferences{}
+	}
+	if b == nil {
+		b = &ExternalPropertyFileReferences{}
+	}
+	for i := 0; i < len(a.Addresses) || i < len(b.Addresses); i++ {
+		if i >= len(a.Addresses) || i >= len(b.Addresses) {
+			d.report(ptr + "/addresses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Addresses[i], b.Addresses[i], ptr+"/addresses"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Artifacts) || i < len(b.Artifacts); i++ {
+		if i >= len(a.Artifacts) || i >= len(b.Artifacts) {
+			d.report(ptr + "/artifacts" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Artifacts[i], b.Artifacts[i], ptr+"/artifacts"+"/"+strconv.Itoa(i))
+	}
+	d.diffExternalPropertyFileReference(a.Conversion, b.Conversion, ptr+"/conversion")
+	d.diffExternalPropertyFileReference(a.Driver, b.Driver, ptr+"/driver")
+	for i := 0; i < len(a.Extensions) || i < len(b.Extensions); i++ {
+		if i >= len(a.Extensions) || i >= len(b.Extensions) {
+			d.report(ptr + "/extensions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Extensions[i], b.Extensions[i], ptr+"/extensions"+"/"+strconv.Itoa(i))
+	}
+	d.diffExternalPropertyFileReference(a.ExternalizedProperties, b.ExternalizedProperties, ptr+"/externalizedProperties")
+	for i := 0; i < len(a.Graphs) || i < len(b.Graphs); i++ {
+		if i >= len(a.Graphs) || i >= len(b.Graphs) {
+			d.report(ptr + "/graphs" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Graphs[i], b.Graphs[i], ptr+"/graphs"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Invocations) || i < len(b.Invocations); i++ {
+		if i >= len(a.Invocations) || i >= len(b.Invocations) {
+			d.report(ptr + "/invocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Invocations[i], b.Invocations[i], ptr+"/invocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.LogicalLocations) || i < len(b.LogicalLocations); i++ {
+		if i >= len(a.LogicalLocations) || i >= len(b.LogicalLocations) {
+			d.report(ptr + "/logicalLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.LogicalLocations[i], b.LogicalLocations[i], ptr+"/logicalLocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Policies) || i < len(b.Policies); i++ {
+		if i >= len(a.Policies) || i >= len(b.Policies) {
+			d.report(ptr + "/policies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Policies[i], b.Policies[i], ptr+"/policies"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Results) || i < len(b.Results); i++ {
+		if i >= len(a.Results) || i >= len(b.Results) {
+			d.report(ptr + "/results" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Results[i], b.Results[i], ptr+"/results"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Taxonomies) || i < len(b.Taxonomies); i++ {
+		if i >= len(a.Taxonomies) || i >= len(b.Taxonomies) {
+			d.report(ptr + "/taxonomies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Taxonomies[i], b.Taxonomies[i], ptr+"/taxonomies"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.ThreadFlowLocations) || i < len(b.ThreadFlowLocations); i++ {
+		if i >= len(a.ThreadFlowLocations) || i >= len(b.ThreadFlowLocations) {
+			d.report(ptr + "/threadFlowLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.ThreadFlowLocations[i], b.ThreadFlowLocations[i], ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Translations) || i < len(b.Translations); i++ {
+		if i >= len(a.Translations) || i >= len(b.Translations) {
+			d.report(ptr + "/translations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.Translations[i], b.Translations[i], ptr+"/translations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.WebRequests) || i < len(b.WebRequests); i++ {
+		if i >= len(a.WebRequests) || i >= len(b.WebRequests) {
+			d.report(ptr + "/webRequests" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.WebRequests[i], b.WebRequests[i], ptr+"/webRequests"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.WebResponses) || i < len(b.WebResponses); i++ {
+		if i >= len(a.WebResponses) || i >= len(b.WebResponses) {
+			d.report(ptr + "/webResponses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalPropertyFileReference(a.WebResponses[i], b.WebResponses[i], ptr+"/webResponses"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Fix) Equal(other *Fix) bool {
+	d := &differ{limit: 1}
+	d.diffFix(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffFix(a, b *Fix, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Fix{}
+	}
+	if b == nil {
+		b = &Fix{}
+	}
+	for i := 0; i < len(a.ArtifactChanges) || i < len(b.ArtifactChanges); i++ {
+		if i >= len(a.ArtifactChanges) || i >= len(b.ArtifactChanges) {
+			d.report(ptr + "/artifactChanges" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifactChange(a.ArtifactChanges[i], b.ArtifactChanges[i], ptr+"/artifactChanges"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Graph) Equal(other *Graph) bool {
+	d := &differ{limit: 1}
+	d.diffGraph(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffGraph(a, b *Graph, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Graph{}
+	}
+	if b == nil {
+		b = &Graph{}
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	for i := 0; i < len(a.Edges) || i < len(b.Edges); i++ {
+		if i >= len(a.Edges) || i >= len(b.Edges) {
+			d.report(ptr + "/edges" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffEdge(a.Edges[i], b.Edges[i], ptr+"/edges"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Nodes) || i < len(b.Nodes); i++ {
+		if i >= len(a.Nodes) || i >= len(b.Nodes) {
+			d.report(ptr + "/nodes" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffNode(a.Nodes[i], b.Nodes[i], ptr+"/nodes"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *GraphTraversal) Equal(other *GraphTraversal) bool {
+	d := &differ{limit: 1}
+	d.diffGraphTraversal(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffGraphTraversal(a, b *GraphTraversal, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &GraphTraversal{}
+	}
+	if b == nil {
+		b = &GraphTraversal{}
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	for i := 0; i < len(a.EdgeTraversals) || i < len(b.EdgeTraversals); i++ {
+		if i >= len(a.EdgeTraversals) || i >= len(b.EdgeTraversals) {
+			d.report(ptr + "/edgeTraversals" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffEdgeTraversal(a.EdgeTraversals[i], b.EdgeTraversals[i], ptr+"/edgeTraversals"+"/"+strconv.Itoa(i))
+	}
+	for _, k := range unionKeys(a.ImmutableState, b.ImmutableState) {
+		av, aok := a.ImmutableState[k]
+		bv, bok := b.ImmutableState[k]
+		if aok != bok {
+			d.report(ptr + "/immutableState" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/immutableState"+"/"+escapePointer(k))
+	}
+	for _, k := range unionKeys(a.InitialState, b.InitialState) {
+		av, aok := a.InitialState[k]
+		bv, bok := b.InitialState[k]
+		if aok != bok {
+			d.report(ptr + "/initialState" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/initialState"+"/"+escapePointer(k))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.ResultGraphIndex, b.ResultGraphIndex, ptr+"/resultGraphIndex")
+	diffValue(d, a.RunGraphIndex, b.RunGraphIndex, ptr+"/runGraphIndex")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Invocation) Equal(other *Invocation) bool {
+	d := &differ{limit: 1}
+	d.diffInvocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffInvocation(a, b *Invocation, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Invocation{}
+	}
+	if b == nil {
+		b = &Invocation{}
+	}
+	diffValue(d, a.Account, b.Account, ptr+"/account")
+	d.diffStrings(a.Arguments, b.Arguments, ptr+"/arguments")
+	diffValue(d, a.CommandLine, b.CommandLine, ptr+"/commandLine")
+	diffValue(d, a.EndTimeUtc, b.EndTimeUtc, ptr+"/endTimeUtc")
+	d.diffStringMap(a.EnvironmentVariables, b.EnvironmentVariables, ptr+"/environmentVariables")
+	d.diffArtifactLocation(a.ExecutableLocation, b.ExecutableLocation, ptr+"/executableLocation")
+	diffValue(d, a.ExecutionSuccessful, b.ExecutionSuccessful, ptr+"/executionSuccessful")
+	diffValue(d, a.ExitCode, b.ExitCode, ptr+"/exitCode")
+	diffValue(d, a.ExitCodeDescription, b.ExitCodeDescription, ptr+"/exitCodeDescription")
+	diffValue(d, a.ExitSignalName, b.ExitSignalName, ptr+"/exitSignalName")
+	diffValue(d, a.ExitSignalNumber, b.ExitSignalNumber, ptr+"/exitSignalNumber")
+	diffValue(d, a.Machine, b.Machine, ptr+"/machine")
+	for i := 0; i < len(a.NotificationConfigurationOverrides) || i < len(b.NotificationConfigurationOverrides); i++ {
+		if i >= len(a.NotificationConfigurationOverrides) || i >= len(b.NotificationConfigurationOverrides) {
+			d.report(ptr + "/notificationConfigurationOverrides" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffConfigurationOverride(a.NotificationConfigurationOverrides[i], b.NotificationConfigurationOverrides[i], ptr+"/notificationConfigurationOverrides"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.ProcessId, b.ProcessId, ptr+"/processId")
+	diffValue(d, a.ProcessStartFailureMessage, b.ProcessStartFailureMessage, ptr+"/processStartFailureMessage")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.ResponseFiles) || i < len(b.ResponseFiles); i++ {
+		if i >= len(a.ResponseFiles) || i >= len(b.ResponseFiles) {
+			d.report(ptr + "/responseFiles" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifactLocation(a.ResponseFiles[i], b.ResponseFiles[i], ptr+"/responseFiles"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.RuleConfigurationOverrides) || i < len(b.RuleConfigurationOverrides); i++ {
+		if i >= len(a.RuleConfigurationOverrides) || i >= len(b.RuleConfigurationOverrides) {
+			d.report(ptr + "/ruleConfigurationOverrides" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffConfigurationOverride(a.RuleConfigurationOverrides[i], b.RuleConfigurationOverrides[i], ptr+"/ruleConfigurationOverrides"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.StartTimeUtc, b.StartTimeUtc, ptr+"/startTimeUtc")
+	d.diffArtifactLocation(a.Stderr, b.Stderr, ptr+"/stderr")
+	d.diffArtifactLocation(a.Stdin, b.Stdin, ptr+"/stdin")
+	d.diffArtifactLocation(a.Stdout, b.Stdout, ptr+"/stdout")
+	d.diffArtifactLocation(a.StdoutStderr, b.StdoutStderr, ptr+"/stdoutStderr")
+	for i := 0; i < len(a.ToolConfigurationNotifications) || i < len(b.ToolConfigurationNotifications); i++ {
+		if i >= len(a.ToolConfigurationNotifications) || i >= len(b.ToolConfigurationNotifications) {
+			d.report(ptr + "/toolConfigurationNotifications" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffNotification(a.ToolConfigurationNotifications[i], b.ToolConfigurationNotifications[i], ptr+"/toolConfigurationNotifications"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.ToolExecutionNotifications) || i < len(b.ToolExecutionNotifications); i++ {
+		if i >= len(a.ToolExecutionNotifications) || i >= len(b.ToolExecutionNotifications) {
+			d.report(ptr + "/toolExecutionNotifications" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffNotification(a.ToolExecutionNotifications[i], b.ToolExecutionNotifications[i], ptr+"/toolExecutionNotifications"+"/"+strconv.Itoa(i))
+	}
+	d.diffArtifactLocation(a.WorkingDirectory, b.WorkingDirectory, ptr+"/workingDirectory")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Location) Equal(other *Location) bool {
+	d := &differ{limit: 1}
+	d.diffLocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffLocation(a, b *Location, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Location{}
+	}
+	if b == nil {
+		b = &Location{}
+	}
+	for i := 0; i < len(a.Annotations) || i < len(b.Annotations); i++ {
+		if i >= len(a.Annotations) || i >= len(b.Annotations) {
+			d.report(ptr + "/annotations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffRegion(a.Annotations[i], b.Annotations[i], ptr+"/annotations"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	for i := 0; i < len(a.LogicalLocations) || i < len(b.LogicalLocations); i++ {
+		if i >= len(a.LogicalLocations) || i >= len(b.LogicalLocations) {
+			d.report(ptr + "/logicalLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLogicalLocation(a.LogicalLocations[i], b.LogicalLocations[i], ptr+"/logicalLocations"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPhysicalLocation(a.PhysicalLocation, b.PhysicalLocation, ptr+"/physicalLocation")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Relationships) || i < len(b.Relationships); i++ {
+		if i >= len(a.Relationships) || i >= len(b.Relationships) {
+			d.report(ptr + "/relationships" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLocationRelationship(a.Relationships[i], b.Relationships[i], ptr+"/relationships"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *LocationRelationship) Equal(other *LocationRelationship) bool {
+	d := &differ{limit: 1}
+	d.diffLocationRelationship(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffLocationRelationship(a, b *LocationRelationship, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &LocationRelationship{}
+	}
+	if b == nil {
+		b = &LocationRelationship{}
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	d.diffStrings(a.Kinds, b.Kinds, ptr+"/kinds")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Target, b.Target, ptr+"/target")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *LogicalLocation) Equal(other *LogicalLocation) bool {
+	d := &differ{limit: 1}
+	d.diffLogicalLocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffLogicalLocation(a, b *LogicalLocation, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &LogicalLocation{}
+	}
+	if b == nil {
+		b = &LogicalLocation{}
+	}
+	diffValue(d, a.DecoratedName, b.DecoratedName, ptr+"/decoratedName")
+	diffValue(d, a.FullyQualifiedName, b.FullyQualifiedName, ptr+"/fullyQualifiedName")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	diffValue(d, a.Kind, b.Kind, ptr+"/kind")
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	diffValue(d, a.ParentIndex, b.ParentIndex, ptr+"/parentIndex")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Message) Equal(other *Message) bool {
+	d := &differ{limit: 1}
+	d.diffMessage(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffMessage(a, b *Message, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Message{}
+	}
+	if b == nil {
+		b = &Message{}
+	}
+	d.diffStrings(a.Arguments, b.Arguments, ptr+"/arguments")
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	diffValue(d, a.Markdown, b.Markdown, ptr+"/markdown")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Text, b.Text, ptr+"/text")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *MultiformatMessageString) Equal(other *MultiformatMessageString) bool {
+	d := &differ{limit: 1}
+	d.diffMultiformatMessageString(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffMultiformatMessageString(a, b *MultiformatMessageString, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &MultiformatMessageString{}
+	}
+	if b == nil {
+		b = &MultiformatMessageString{}
+	}
+	diffValue(d, a.Markdown, b.Markdown, ptr+"/markdown")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Text, b.Text, ptr+"/text")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Node) Equal(other *Node) bool {
+	d := &differ{limit: 1}
+	d.diffNode(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffNode(a, b *Node, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Node{}
+	}
+	if b == nil {
+		b = &Node{}
+	}
+	for i := 0; i < len(a.Children) || i < len(b.Children); i++ {
+		if i >= len(a.Children) || i >= len(b.Children) {
+			d.report(ptr + "/children" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffNode(a.Children[i], b.Children[i], ptr+"/children"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	d.diffMessage(a.Label, b.Label, ptr+"/label")
+	d.diffLocation(a.Location, b.Location, ptr+"/location")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Notification) Equal(other *Notification) bool {
+	d := &differ{limit: 1}
+	d.diffNotification(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffNotification(a, b *Notification, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Notification{}
+	}
+	if b == nil {
+		b = &Notification{}
+	}
+	d.diffReportingDescriptorReference(a.AssociatedRule, b.AssociatedRule, ptr+"/associatedRule")
+	d.diffReportingDescriptorReference(a.Descriptor, b.Descriptor, ptr+"/descriptor")
+	d.diffException(a.Exception, b.Exception, ptr+"/exception")
+	diffValue(d, withDefault(a.Level, "warning"), withDefault(b.Level, "warning"), ptr+"/level")
+	for i := 0; i < len(a.Locations) || i < len(b.Locations); i++ {
+		if i >= len(a.Locations) || i >= len(b.Locations) {
+			d.report(ptr + "/locations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLocation(a.Locations[i], b.Locations[i], ptr+"/locations"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.ThreadId, b.ThreadId, ptr+"/threadId")
+	diffValue(d, a.TimeUtc, b.TimeUtc, ptr+"/timeUtc")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *PhysicalLocation) Equal(other *PhysicalLocation) bool {
+	d := &differ{limit: 1}
+	d.diffPhysicalLocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffPhysicalLocation(a, b *PhysicalLocation, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &PhysicalLocation{}
+	}
+	if b == nil {
+		b = &PhysicalLocation{}
+	}
+	d.diffAddress(a.Address, b.Address, ptr+"/address")
+	d.diffArtifactLocation(a.ArtifactLocation, b.ArtifactLocation, ptr+"/artifactLocation")
+	d.diffRegion(a.ContextRegion, b.ContextRegion, ptr+"/contextRegion")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffRegion(a.Region, b.Region, ptr+"/region")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *PropertyBag) Equal(other *PropertyBag) bool {
+	d := &differ{limit: 1}
+	d.diffPropertyBag(strct, other, "")
+	return len(d.paths) == 0
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Rectangle) Equal(other *Rectangle) bool {
+	d := &differ{limit: 1}
+	d.diffRectangle(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffRectangle(a, b *Rectangle, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Rectangle{}
+	}
+	if b == nil {
+		b = &Rectangle{}
+	}
+	diffValue(d, a.Bottom, b.Bottom, ptr+"/bottom")
+	diffValue(d, a.Left, b.Left, ptr+"/left")
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Right, b.Right, ptr+"/right")
+	diffValue(d, a.Top, b.Top, ptr+"/top")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Region) Equal(other *Region) bool {
+	d := &differ{limit: 1}
+	d.diffRegion(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffRegion(a, b *Region, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Region{}
+	}
+	if b == nil {
+		b = &Region{}
+	}
+	diffValue(d, a.ByteLength, b.ByteLength, ptr+"/byteLength")
+	diffValue(d, a.ByteOffset, b.ByteOffset, ptr+"/byteOffset")
+	diffValue(d, a.CharLength, b.CharLength, ptr+"/charLength")
+	diffValue(d, a.CharOffset, b.CharOffset, ptr+"/charOffset")
+	diffValue(d, a.EndColumn, b.EndColumn, ptr+"/endColumn")
+	diffValue(d, a.EndLine, b.EndLine, ptr+"/endLine")
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffArtifactContent(a.Snippet, b.Snippet, ptr+"/snippet")
+	diffValue(d, a.SourceLanguage, b.SourceLanguage, ptr+"/sourceLanguage")
+	diffValue(d, a.StartColumn, b.StartColumn, ptr+"/startColumn")
+	diffValue(d, a.StartLine, b.StartLine, ptr+"/startLine")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Replacement) Equal(other *Replacement) bool {
+	d := &differ{limit: 1}
+	d.diffReplacement(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffReplacement(a, b *Replacement, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Replacement{}
+	}
+	if b == nil {
+		b = &Replacement{}
+	}
+	d.diffRegion(a.DeletedRegion, b.DeletedRegion, ptr+"/deletedRegion")
+	d.diffArtifactContent(a.InsertedContent, b.InsertedContent, ptr+"/insertedContent")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ReportingConfiguration) Equal(other *ReportingConfiguration) bool {
+	d := &differ{limit: 1}
+	d.diffReportingConfiguration(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffReportingConfiguration(a, b *ReportingConfiguration, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ReportingConfiguration{}
+	}
+	if b == nil {
+		b = &ReportingConfiguration{}
+	}
+	diffValue(d, a.Enabled, b.Enabled, ptr+"/enabled")
+	diffValue(d, withDefault(a.Level, "warning"), withDefault(b.Level, "warning"), ptr+"/level")
+	d.diffPropertyBag(a.Parameters, b.Parameters, ptr+"/parameters")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Rank, b.Rank, ptr+"/rank")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ReportingDescriptor) Equal(other *ReportingDescriptor) bool {
+	d := &differ{limit: 1}
+	d.diffReportingDescriptor(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffReportingDescriptor(a, b *ReportingDescriptor, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ReportingDescriptor{}
+	}
+	if b == nil {
+		b = &ReportingDescriptor{}
+	}
+	d.diffReportingConfiguration(a.DefaultConfiguration, b.DefaultConfiguration, ptr+"/defaultConfiguration")
+	d.diffStrings(a.DeprecatedGuids, b.DeprecatedGuids, ptr+"/deprecatedGuids")
+	d.diffStrings(a.DeprecatedIds, b.DeprecatedIds, ptr+"/deprecatedIds")
+	d.diffStrings(a.DeprecatedNames, b.DeprecatedNames, ptr+"/deprecatedNames")
+	d.diffMultiformatMessageString(a.FullDescription, b.FullDescription, ptr+"/fullDescription")
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	d.diffMultiformatMessageString(a.Help, b.Help, ptr+"/help")
+	diffValue(d, a.HelpUri, b.HelpUri, ptr+"/helpUri")
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	for _, k := range unionKeys(a.MessageStrings, b.MessageStrings) {
+		av, aok := a.MessageStrings[k]
+		bv, bok := b.MessageStrings[k]
+		if aok != bok {
+			d.report(ptr + "/messageStrings" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/messageStrings"+"/"+escapePointer(k))
+	}
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Relationships) || i < len(b.Relationships); i++ {
+		if i >= len(a.Relationships) || i >= len(b.Relationships) {
+			d.report(ptr + "/relationships" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptorRelationship(a.Relationships[i], b.Relationships[i], ptr+"/relationships"+"/"+strconv.Itoa(i))
+	}
+	d.diffMultiformatMessageString(a.ShortDescription, b.ShortDescription, ptr+"/shortDescription")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ReportingDescriptorReference) Equal(other *ReportingDescriptorReference) bool {
+	d := &differ{limit: 1}
+	d.diffReportingDescriptorReference(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffReportingDescriptorReference(a, b *ReportingDescriptorReference, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ReportingDescriptorReference{}
+	}
+	if b == nil {
+		b = &ReportingDescriptorReference{}
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffToolComponentReference(a.ToolComponent, b.ToolComponent, ptr+"/toolComponent")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ReportingDescriptorRelationship) Equal(other *ReportingDescriptorRelationship) bool {
+	d := &differ{limit: 1}
+	d.diffReportingDescriptorRelationship(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffReportingDescriptorRelationship(a, b *ReportingDescriptorRelationship, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ReportingDescriptorRelationship{}
+	}
+	if b == nil {
+		b = &ReportingDescriptorRelationship{}
+	}
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	d.diffStrings(a.Kinds, b.Kinds, ptr+"/kinds")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffReportingDescriptorReference(a.Target, b.Target, ptr+"/target")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Result) Equal(other *Result) bool {
+	d := &differ{limit: 1}
+	d.diffResult(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffResult(a, b *Result, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Result{}
+	}
+	if b == nil {
+		b = &Result{}
+	}
+	d.diffArtifactLocation(a.AnalysisTarget, b.AnalysisTarget, ptr+"/analysisTarget")
+	for i := 0; i < len(a.Attachments) || i < len(b.Attachments); i++ {
+		if i >= len(a.Attachments) || i >= len(b.Attachments) {
+			d.report(ptr + "/attachments" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffAttachment(a.Attachments[i], b.Attachments[i], ptr+"/attachments"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.BaselineState, b.BaselineState, ptr+"/baselineState")
+	for i := 0; i < len(a.CodeFlows) || i < len(b.CodeFlows); i++ {
+		if i >= len(a.CodeFlows) || i >= len(b.CodeFlows) {
+			d.report(ptr + "/codeFlows" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffCodeFlow(a.CodeFlows[i], b.CodeFlows[i], ptr+"/codeFlows"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.CorrelationGuid, b.CorrelationGuid, ptr+"/correlationGuid")
+	d.diffStringMap(a.Fingerprints, b.Fingerprints, ptr+"/fingerprints")
+	for i := 0; i < len(a.Fixes) || i < len(b.Fixes); i++ {
+		if i >= len(a.Fixes) || i >= len(b.Fixes) {
+			d.report(ptr + "/fixes" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffFix(a.Fixes[i], b.Fixes[i], ptr+"/fixes"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.GraphTraversals) || i < len(b.GraphTraversals); i++ {
+		if i >= len(a.GraphTraversals) || i >= len(b.GraphTraversals) {
+			d.report(ptr + "/graphTraversals" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffGraphTraversal(a.GraphTraversals[i], b.GraphTraversals[i], ptr+"/graphTraversals"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Graphs) || i < len(b.Graphs); i++ {
+		if i >= len(a.Graphs) || i >= len(b.Graphs) {
+			d.report(ptr + "/graphs" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffGraph(a.Graphs[i], b.Graphs[i], ptr+"/graphs"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.HostedViewerUri, b.HostedViewerUri, ptr+"/hostedViewerUri")
+	diffValue(d, withDefault(a.Kind, "fail"), withDefault(b.Kind, "fail"), ptr+"/kind")
+	diffValue(d, a.Level, b.Level, ptr+"/level")
+	for i := 0; i < len(a.Locations) || i < len(b.Locations); i++ {
+		if i >= len(a.Locations) || i >= len(b.Locations) {
+			d.report(ptr + "/locations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLocation(a.Locations[i], b.Locations[i], ptr+"/locations"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	diffValue(d, a.OccurrenceCount, b.OccurrenceCount, ptr+"/occurrenceCount")
+	d.diffStringMap(a.PartialFingerprints, b.PartialFingerprints, ptr+"/partialFingerprints")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffResultProvenance(a.Provenance, b.Provenance, ptr+"/provenance")
+	diffValue(d, a.Rank, b.Rank, ptr+"/rank")
+	for i := 0; i < len(a.RelatedLocations) || i < len(b.RelatedLocations); i++ {
+		if i >= len(a.RelatedLocations) || i >= len(b.RelatedLocations) {
+			d.report(ptr + "/relatedLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLocation(a.RelatedLocations[i], b.RelatedLocations[i], ptr+"/relatedLocations"+"/"+strconv.Itoa(i))
+	}
+	d.diffReportingDescriptorReference(a.Rule, b.Rule, ptr+"/rule")
+	diffValue(d, a.RuleId, b.RuleId, ptr+"/ruleId")
+	diffValue(d, a.RuleIndex, b.RuleIndex, ptr+"/ruleIndex")
+	for i := 0; i < len(a.Stacks) || i < len(b.Stacks); i++ {
+		if i >= len(a.Stacks) || i >= len(b.Stacks) {
+			d.report(ptr + "/stacks" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffStack(a.Stacks[i], b.Stacks[i], ptr+"/stacks"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Suppressions) || i < len(b.Suppressions); i++ {
+		if i >= len(a.Suppressions) || i >= len(b.Suppressions) {
+			d.report(ptr + "/suppressions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffSuppression(a.Suppressions[i], b.Suppressions[i], ptr+"/suppressions"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Taxa) || i < len(b.Taxa); i++ {
+		if i >= len(a.Taxa) || i >= len(b.Taxa) {
+			d.report(ptr + "/taxa" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptorReference(a.Taxa[i], b.Taxa[i], ptr+"/taxa"+"/"+strconv.Itoa(i))
+	}
+	d.diffWebRequest(a.WebRequest, b.WebRequest, ptr+"/webRequest")
+	d.diffWebResponse(a.WebResponse, b.WebResponse, ptr+"/webResponse")
+	d.diffStrings(a.WorkItemUris, b.WorkItemUris, ptr+"/workItemUris")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ResultProvenance) Equal(other *ResultProvenance) bool {
+	d := &differ{limit: 1}
+	d.diffResultProvenance(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffResultProvenance(a, b *ResultProvenance, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ResultProvenance{}
+	}
+	if b == nil {
+		b = &ResultProvenance{}
+	}
+	for i := 0; i < len(a.ConversionSources) || i < len(b.ConversionSources); i++ {
+		if i >= len(a.ConversionSources) || i >= len(b.ConversionSources) {
+			d.report(ptr + "/conversionSources" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffPhysicalLocation(a.ConversionSources[i], b.ConversionSources[i], ptr+"/conversionSources"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.FirstDetectionRunGuid, b.FirstDetectionRunGuid, ptr+"/firstDetectionRunGuid")
+	diffValue(d, a.FirstDetectionTimeUtc, b.FirstDetectionTimeUtc, ptr+"/firstDetectionTimeUtc")
+	diffValue(d, a.InvocationIndex, b.InvocationIndex, ptr+"/invocationIndex")
+	diffValue(d, a.LastDetectionRunGuid, b.LastDetectionRunGuid, ptr+"/lastDetectionRunGuid")
+	diffValue(d, a.LastDetectionTimeUtc, b.LastDetectionTimeUtc, ptr+"/lastDetectionTimeUtc")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Run) Equal(other *Run) bool {
+	d := &differ{limit: 1}
+	d.diffRun(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffRun(a, b *Run, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Run{}
+	}
+	if b == nil {
+		b = &Run{}
+	}
+	for i := 0; i < len(a.Addresses) || i < len(b.Addresses); i++ {
+		if i >= len(a.Addresses) || i >= len(b.Addresses) {
+			d.report(ptr + "/addresses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffAddress(a.Addresses[i], b.Addresses[i], ptr+"/addresses"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Artifacts) || i < len(b.Artifacts); i++ {
+		if i >= len(a.Artifacts) || i >= len(b.Artifacts) {
+			d.report(ptr + "/artifacts" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifact(a.Artifacts[i], b.Artifacts[i], ptr+"/artifacts"+"/"+strconv.Itoa(i))
+	}
+	d.diffRunAutomationDetails(a.AutomationDetails, b.AutomationDetails, ptr+"/automationDetails")
+	diffValue(d, a.BaselineGuid, b.BaselineGuid, ptr+"/baselineGuid")
+	diffValue(d, a.ColumnKind, b.ColumnKind, ptr+"/columnKind")
+	d.diffConversion(a.Conversion, b.Conversion, ptr+"/conversion")
+	diffValue(d, a.DefaultEncoding, b.DefaultEncoding, ptr+"/defaultEncoding")
+	diffValue(d, a.DefaultSourceLanguage, b.DefaultSourceLanguage, ptr+"/defaultSourceLanguage")
+	d.diffExternalPropertyFileReferences(a.ExternalPropertyFileReferences, b.ExternalPropertyFileReferences, ptr+"/externalPropertyFileReferences")
+	for i := 0; i < len(a.Graphs) || i < len(b.Graphs); i++ {
+		if i >= len(a.Graphs) || i >= len(b.Graphs) {
+			d.report(ptr + "/graphs" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffGraph(a.Graphs[i], b.Graphs[i], ptr+"/graphs"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Invocations) || i < len(b.Invocations); i++ {
+		if i >= len(a.Invocations) || i >= len(b.Invocations) {
+			d.report(ptr + "/invocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffInvocation(a.Invocations[i], b.Invocations[i], ptr+"/invocations"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Language, b.Language, ptr+"/language")
+	for i := 0; i < len(a.LogicalLocations) || i < len(b.LogicalLocations); i++ {
+		if i >= len(a.LogicalLocations) || i >= len(b.LogicalLocations) {
+			d.report(ptr + "/logicalLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffLogicalLocation(a.LogicalLocations[i], b.LogicalLocations[i], ptr+"/logicalLocations"+"/"+strconv.Itoa(i))
+	}
+	d.diffStrings(a.NewlineSequences, b.NewlineSequences, ptr+"/newlineSequences")
+	for _, k := range unionKeys(a.OriginalUriBaseIds, b.OriginalUriBaseIds) {
+		av, aok := a.OriginalUriBaseIds[k]
+		bv, bok := b.OriginalUriBaseIds[k]
+		if aok != bok {
+			d.report(ptr + "/originalUriBaseIds" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffArtifactLocation(av, bv, ptr+"/originalUriBaseIds"+"/"+escapePointer(k))
+	}
+	for i := 0; i < len(a.Policies) || i < len(b.Policies); i++ {
+		if i >= len(a.Policies) || i >= len(b.Policies) {
+			d.report(ptr + "/policies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Policies[i], b.Policies[i], ptr+"/policies"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffStrings(a.RedactionTokens, b.RedactionTokens, ptr+"/redactionTokens")
+	for i := 0; i < len(a.Results) || i < len(b.Results); i++ {
+		if i >= len(a.Results) || i >= len(b.Results) {
+			d.report(ptr + "/results" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffResult(a.Results[i], b.Results[i], ptr+"/results"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.RunAggregates) || i < len(b.RunAggregates); i++ {
+		if i >= len(a.RunAggregates) || i >= len(b.RunAggregates) {
+			d.report(ptr + "/runAggregates" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffRunAutomationDetails(a.RunAggregates[i], b.RunAggregates[i], ptr+"/runAggregates"+"/"+strconv.Itoa(i))
+	}
+	d.diffSpecialLocations(a.SpecialLocations, b.SpecialLocations, ptr+"/specialLocations")
+	for i := 0; i < len(a.Taxonomies) || i < len(b.Taxonomies); i++ {
+		if i >= len(a.Taxonomies) || i >= len(b.Taxonomies) {
+			d.report(ptr + "/taxonomies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Taxonomies[i], b.Taxonomies[i], ptr+"/taxonomies"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.ThreadFlowLocations) || i < len(b.ThreadFlowLocations); i++ {
+		if i >= len(a.ThreadFlowLocations) || i >= len(b.ThreadFlowLocations) {
+			d.report(ptr + "/threadFlowLocations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffThreadFlowLocation(a.ThreadFlowLocations[i], b.ThreadFlowLocations[i], ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i))
+	}
+	d.diffTool(a.Tool, b.Tool, ptr+"/tool")
+	for i := 0; i < len(a.Translations) || i < len(b.Translations); i++ {
+		if i >= len(a.Translations) || i >= len(b.Translations) {
+			d.report(ptr + "/translations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Translations[i], b.Translations[i], ptr+"/translations"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.VersionControlProvenance) || i < len(b.VersionControlProvenance); i++ {
+		if i >= len(a.VersionControlProvenance) || i >= len(b.VersionControlProvenance) {
+			d.report(ptr + "/versionControlProvenance" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffVersionControlDetails(a.VersionControlProvenance[i], b.VersionControlProvenance[i], ptr+"/versionControlProvenance"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.WebRequests) || i < len(b.WebRequests); i++ {
+		if i >= len(a.WebRequests) || i >= len(b.WebRequests) {
+			d.report(ptr + "/webRequests" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffWebRequest(a.WebRequests[i], b.WebRequests[i], ptr+"/webRequests"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.WebResponses) || i < len(b.WebResponses); i++ {
+		if i >= len(a.WebResponses) || i >= len(b.WebResponses) {
+			d.report(ptr + "/webResponses" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffWebResponse(a.WebResponses[i], b.WebResponses[i], ptr+"/webResponses"+"/"+strconv.Itoa(i))
+	}
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *RunAutomationDetails) Equal(other *RunAutomationDetails) bool {
+	d := &differ{limit: 1}
+	d.diffRunAutomationDetails(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffRunAutomationDetails(a, b *RunAutomationDetails, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &RunAutomationDetails{}
+	}
+	if b == nil {
+		b = &RunAutomationDetails{}
+	}
+	diffValue(d, a.CorrelationGuid, b.CorrelationGuid, ptr+"/correlationGuid")
+	d.diffMessage(a.Description, b.Description, ptr+"/description")
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *SARIF) Equal(other *SARIF) bool {
+	d := &differ{limit: 1}
+	d.diffSARIF(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffSARIF(a, b *SARIF, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &SARIF{}
+	}
+	if b == nil {
+		b = &SARIF{}
+	}
+	for i := 0; i < len(a.InlineExternalProperties) || i < len(b.InlineExternalProperties); i++ {
+		if i >= len(a.InlineExternalProperties) || i >= len(b.InlineExternalProperties) {
+			d.report(ptr + "/inlineExternalProperties" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffExternalProperties(a.InlineExternalProperties[i], b.InlineExternalProperties[i], ptr+"/inlineExternalProperties"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	for i := 0; i < len(a.Runs) || i < len(b.Runs); i++ {
+		if i >= len(a.Runs) || i >= len(b.Runs) {
+			d.report(ptr + "/runs" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffRun(a.Runs[i], b.Runs[i], ptr+"/runs"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Schema, b.Schema, ptr+"/$schema")
+	diffValue(d, a.Version, b.Version, ptr+"/version")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *SpecialLocations) Equal(other *SpecialLocations) bool {
+	d := &differ{limit: 1}
+	d.diffSpecialLocations(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffSpecialLocations(a, b *SpecialLocations, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &SpecialLocations{}
+	}
+	if b == nil {
+		b = &SpecialLocations{}
+	}
+	d.diffArtifactLocation(a.DisplayBase, b.DisplayBase, ptr+"/displayBase")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Stack) Equal(other *Stack) bool {
+	d := &differ{limit: 1}
+	d.diffStack(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffStack(a, b *Stack, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Stack{}
+	}
+	if b == nil {
+		b = &Stack{}
+	}
+	for i := 0; i < len(a.Frames) || i < len(b.Frames); i++ {
+		if i >= len(a.Frames) || i >= len(b.Frames) {
+			d.report(ptr + "/frames" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffStackFrame(a.Frames[i], b.Frames[i], ptr+"/frames"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *StackFrame) Equal(other *StackFrame) bool {
+	d := &differ{limit: 1}
+	d.diffStackFrame(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffStackFrame(a, b *StackFrame, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &StackFrame{}
+	}
+	if b == nil {
+		b = &StackFrame{}
+	}
+	d.diffLocation(a.Location, b.Location, ptr+"/location")
+	diffValue(d, a.Module, b.Module, ptr+"/module")
+	d.diffStrings(a.Parameters, b.Parameters, ptr+"/parameters")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.ThreadId, b.ThreadId, ptr+"/threadId")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Suppression) Equal(other *Suppression) bool {
+	d := &differ{limit: 1}
+	d.diffSuppression(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffSuppression(a, b *Suppression, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Suppression{}
+	}
+	if b == nil {
+		b = &Suppression{}
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.Justification, b.Justification, ptr+"/justification")
+	diffValue(d, a.Kind, b.Kind, ptr+"/kind")
+	d.diffLocation(a.Location, b.Location, ptr+"/location")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.State, b.State, ptr+"/state")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ThreadFlow) Equal(other *ThreadFlow) bool {
+	d := &differ{limit: 1}
+	d.diffThreadFlow(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffThreadFlow(a, b *ThreadFlow, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ThreadFlow{}
+	}
+	if b == nil {
+		b = &ThreadFlow{}
+	}
+	diffValue(d, a.Id, b.Id, ptr+"/id")
+	for _, k := range unionKeys(a.ImmutableState, b.ImmutableState) {
+		av, aok := a.ImmutableState[k]
+		bv, bok := b.ImmutableState[k]
+		if aok != bok {
+			d.report(ptr + "/immutableState" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/immutableState"+"/"+escapePointer(k))
+	}
+	for _, k := range unionKeys(a.InitialState, b.InitialState) {
+		av, aok := a.InitialState[k]
+		bv, bok := b.InitialState[k]
+		if aok != bok {
+			d.report(ptr + "/initialState" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/initialState"+"/"+escapePointer(k))
+	}
+	for i := 0; i < len(a.Locations) || i < len(b.Locations); i++ {
+		if i >= len(a.Locations) || i >= len(b.Locations) {
+			d.report(ptr + "/locations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffThreadFlowLocation(a.Locations[i], b.Locations[i], ptr+"/locations"+"/"+strconv.Itoa(i))
+	}
+	d.diffMessage(a.Message, b.Message, ptr+"/message")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ThreadFlowLocation) Equal(other *ThreadFlowLocation) bool {
+	d := &differ{limit: 1}
+	d.diffThreadFlowLocation(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffThreadFlowLocation(a, b *ThreadFlowLocation, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ThreadFlowLocation{}
+	}
+	if b == nil {
+		b = &ThreadFlowLocation{}
+	}
+	diffValue(d, a.ExecutionOrder, b.ExecutionOrder, ptr+"/executionOrder")
+	diffValue(d, a.ExecutionTimeUtc, b.ExecutionTimeUtc, ptr+"/executionTimeUtc")
+	diffValue(d, withDefault(a.Importance, "important"), withDefault(b.Importance, "important"), ptr+"/importance")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	d.diffStrings(a.Kinds, b.Kinds, ptr+"/kinds")
+	d.diffLocation(a.Location, b.Location, ptr+"/location")
+	diffValue(d, a.Module, b.Module, ptr+"/module")
+	diffValue(d, a.NestingLevel, b.NestingLevel, ptr+"/nestingLevel")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffStack(a.Stack, b.Stack, ptr+"/stack")
+	for _, k := range unionKeys(a.State, b.State) {
+		av, aok := a.State[k]
+		bv, bok := b.State[k]
+		if aok != bok {
+			d.report(ptr + "/state" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/state"+"/"+escapePointer(k))
+	}
+	for i := 0; i < len(a.Taxa) || i < len(b.Taxa); i++ {
+		if i >= len(a.Taxa) || i >= len(b.Taxa) {
+			d.report(ptr + "/taxa" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptorReference(a.Taxa[i], b.Taxa[i], ptr+"/taxa"+"/"+strconv.Itoa(i))
+	}
+	d.diffWebRequest(a.WebRequest, b.WebRequest, ptr+"/webRequest")
+	d.diffWebResponse(a.WebResponse, b.WebResponse, ptr+"/webResponse")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *Tool) Equal(other *Tool) bool {
+	d := &differ{limit: 1}
+	d.diffTool(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffTool(a, b *Tool, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &Tool{}
+	}
+	if b == nil {
+		b = &Tool{}
+	}
+	d.diffToolComponent(a.Driver, b.Driver, ptr+"/driver")
+	for i := 0; i < len(a.Extensions) || i < len(b.Extensions); i++ {
+		if i >= len(a.Extensions) || i >= len(b.Extensions) {
+			d.report(ptr + "/extensions" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponent(a.Extensions[i], b.Extensions[i], ptr+"/extensions"+"/"+strconv.Itoa(i))
+	}
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ToolComponent) Equal(other *ToolComponent) bool {
+	d := &differ{limit: 1}
+	d.diffToolComponent(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffToolComponent(a, b *ToolComponent, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ToolComponent{}
+	}
+	if b == nil {
+		b = &ToolComponent{}
+	}
+	d.diffToolComponentReference(a.AssociatedComponent, b.AssociatedComponent, ptr+"/associatedComponent")
+	diffValue(d, a.Contents, b.Contents, ptr+"/contents")
+	diffValue(d, a.DottedQuadFileVersion, b.DottedQuadFileVersion, ptr+"/dottedQuadFileVersion")
+	diffValue(d, a.DownloadUri, b.DownloadUri, ptr+"/downloadUri")
+	d.diffMultiformatMessageString(a.FullDescription, b.FullDescription, ptr+"/fullDescription")
+	diffValue(d, a.FullName, b.FullName, ptr+"/fullName")
+	for _, k := range unionKeys(a.GlobalMessageStrings, b.GlobalMessageStrings) {
+		av, aok := a.GlobalMessageStrings[k]
+		bv, bok := b.GlobalMessageStrings[k]
+		if aok != bok {
+			d.report(ptr + "/globalMessageStrings" + "/" + escapePointer(k))
+			continue
+		}
+		d.diffMultiformatMessageString(av, bv, ptr+"/globalMessageStrings"+"/"+escapePointer(k))
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.InformationUri, b.InformationUri, ptr+"/informationUri")
+	diffValue(d, a.IsComprehensive, b.IsComprehensive, ptr+"/isComprehensive")
+	diffValue(d, a.Language, b.Language, ptr+"/language")
+	diffValue(d, a.LocalizedDataSemanticVersion, b.LocalizedDataSemanticVersion, ptr+"/localizedDataSemanticVersion")
+	for i := 0; i < len(a.Locations) || i < len(b.Locations); i++ {
+		if i >= len(a.Locations) || i >= len(b.Locations) {
+			d.report(ptr + "/locations" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffArtifactLocation(a.Locations[i], b.Locations[i], ptr+"/locations"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.MinimumRequiredLocalizedDataSemanticVersion, b.MinimumRequiredLocalizedDataSemanticVersion, ptr+"/minimumRequiredLocalizedDataSemanticVersion")
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	for i := 0; i < len(a.Notifications) || i < len(b.Notifications); i++ {
+		if i >= len(a.Notifications) || i >= len(b.Notifications) {
+			d.report(ptr + "/notifications" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptor(a.Notifications[i], b.Notifications[i], ptr+"/notifications"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.Organization, b.Organization, ptr+"/organization")
+	diffValue(d, a.Product, b.Product, ptr+"/product")
+	diffValue(d, a.ProductSuite, b.ProductSuite, ptr+"/productSuite")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.ReleaseDateUtc, b.ReleaseDateUtc, ptr+"/releaseDateUtc")
+	for i := 0; i < len(a.Rules) || i < len(b.Rules); i++ {
+		if i >= len(a.Rules) || i >= len(b.Rules) {
+			d.report(ptr + "/rules" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptor(a.Rules[i], b.Rules[i], ptr+"/rules"+"/"+strconv.Itoa(i))
+	}
+	diffValue(d, a.SemanticVersion, b.SemanticVersion, ptr+"/semanticVersion")
+	d.diffMultiformatMessageString(a.ShortDescription, b.ShortDescription, ptr+"/shortDescription")
+	for i := 0; i < len(a.SupportedTaxonomies) || i < len(b.SupportedTaxonomies); i++ {
+		if i >= len(a.SupportedTaxonomies) || i >= len(b.SupportedTaxonomies) {
+			d.report(ptr + "/supportedTaxonomies" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffToolComponentReference(a.SupportedTaxonomies[i], b.SupportedTaxonomies[i], ptr+"/supportedTaxonomies"+"/"+strconv.Itoa(i))
+	}
+	for i := 0; i < len(a.Taxa) || i < len(b.Taxa); i++ {
+		if i >= len(a.Taxa) || i >= len(b.Taxa) {
+			d.report(ptr + "/taxa" + "/" + strconv.Itoa(i))
+			continue
+		}
+		d.diffReportingDescriptor(a.Taxa[i], b.Taxa[i], ptr+"/taxa"+"/"+strconv.Itoa(i))
+	}
+	d.diffTranslationMetadata(a.TranslationMetadata, b.TranslationMetadata, ptr+"/translationMetadata")
+	diffValue(d, a.Version, b.Version, ptr+"/version")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *ToolComponentReference) Equal(other *ToolComponentReference) bool {
+	d := &differ{limit: 1}
+	d.diffToolComponentReference(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffToolComponentReference(a, b *ToolComponentReference, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &ToolComponentReference{}
+	}
+	if b == nil {
+		b = &ToolComponentReference{}
+	}
+	diffValue(d, a.Guid, b.Guid, ptr+"/guid")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *TranslationMetadata) Equal(other *TranslationMetadata) bool {
+	d := &differ{limit: 1}
+	d.diffTranslationMetadata(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffTranslationMetadata(a, b *TranslationMetadata, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &TranslationMetadata{}
+	}
+	if b == nil {
+		b = &TranslationMetadata{}
+	}
+	diffValue(d, a.DownloadUri, b.DownloadUri, ptr+"/downloadUri")
+	d.diffMultiformatMessageString(a.FullDescription, b.FullDescription, ptr+"/fullDescription")
+	diffValue(d, a.FullName, b.FullName, ptr+"/fullName")
+	diffValue(d, a.InformationUri, b.InformationUri, ptr+"/informationUri")
+	diffValue(d, a.Name, b.Name, ptr+"/name")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	d.diffMultiformatMessageString(a.ShortDescription, b.ShortDescription, ptr+"/shortDescription")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *VersionControlDetails) Equal(other *VersionControlDetails) bool {
+	d := &differ{limit: 1}
+	d.diffVersionControlDetails(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffVersionControlDetails(a, b *VersionControlDetails, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &VersionControlDetails{}
+	}
+	if b == nil {
+		b = &VersionControlDetails{}
+	}
+	diffValue(d, a.AsOfTimeUtc, b.AsOfTimeUtc, ptr+"/asOfTimeUtc")
+	diffValue(d, a.Branch, b.Branch, ptr+"/branch")
+	d.diffArtifactLocation(a.MappedTo, b.MappedTo, ptr+"/mappedTo")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.RepositoryUri, b.RepositoryUri, ptr+"/repositoryUri")
+	diffValue(d, a.RevisionId, b.RevisionId, ptr+"/revisionId")
+	diffValue(d, a.RevisionTag, b.RevisionTag, ptr+"/revisionTag")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *WebRequest) Equal(other *WebRequest) bool {
+	d := &differ{limit: 1}
+	d.diffWebRequest(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffWebRequest(a, b *WebRequest, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &WebRequest{}
+	}
+	if b == nil {
+		b = &WebRequest{}
+	}
+	d.diffArtifactContent(a.Body, b.Body, ptr+"/body")
+	d.diffStringMap(a.Headers, b.Headers, ptr+"/headers")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	diffValue(d, a.Method, b.Method, ptr+"/method")
+	d.diffStringMap(a.Parameters, b.Parameters, ptr+"/parameters")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Protocol, b.Protocol, ptr+"/protocol")
+	diffValue(d, a.Target, b.Target, ptr+"/target")
+	diffValue(d, a.Version, b.Version, ptr+"/version")
+}
+
+// Equal reports whether strct and other are semantically equal, as defined
+// by Diff.
+func (strct *WebResponse) Equal(other *WebResponse) bool {
+	d := &differ{limit: 1}
+	d.diffWebResponse(strct, other, "")
+	return len(d.paths) == 0
+}
+
+func (d *differ) diffWebResponse(a, b *WebResponse, ptr string) {
+	if a == b || d.done() {
+		return
+	}
+	if a == nil {
+		a = &WebResponse{}
+	}
+	if b == nil {
+		b = &WebResponse{}
+	}
+	d.diffArtifactContent(a.Body, b.Body, ptr+"/body")
+	d.diffStringMap(a.Headers, b.Headers, ptr+"/headers")
+	diffValue(d, a.Index, b.Index, ptr+"/index")
+	diffValue(d, a.NoResponseReceived, b.NoResponseReceived, ptr+"/noResponseReceived")
+	d.diffPropertyBag(a.Properties, b.Properties, ptr+"/properties")
+	diffValue(d, a.Protocol, b.Protocol, ptr+"/protocol")
+	diffValue(d, a.ReasonPhrase, b.ReasonPhrase, ptr+"/reasonPhrase")
+	diffValue(d, a.StatusCode, b.StatusCode, ptr+"/statusCode")
+	diffValue(d, a.Version, b.Version, ptr+"/version")
+}
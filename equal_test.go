@@ -0,0 +1,73 @@
+package sarif
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		want []string
+	}{
+		{"equal", &Result{RuleId: "R"}, &Result{RuleId: "R"}, nil},
+		{"nil and empty object", (*Result)(nil), &Result{}, nil},
+		{"nil and empty slice", &Result{Locations: nil}, &Result{Locations: []*Location{}}, nil},
+		{"spec default", &Result{}, &Result{Kind: "fail"}, nil},
+		{"differing default", &Result{}, &Result{Kind: "pass"}, []string{"/kind"}},
+		{"tags as a set", &PropertyBag{Tags: []string{"a", "b"}}, &PropertyBag{Tags: []string{"b", "a"}}, nil},
+		{"different tags", &PropertyBag{Tags: []string{"a"}}, &PropertyBag{Tags: []string{"a", "b"}}, []string{"/tags"}},
+		{
+			"numbers by encoding",
+			&PropertyBag{AdditionalProperties: map[string]interface{}{"n": 1}},
+			&PropertyBag{AdditionalProperties: map[string]interface{}{"n": float64(1)}},
+			nil,
+		},
+		{
+			"nested field",
+			&Result{Message: &Message{Text: "a"}},
+			&Result{Message: &Message{Text: "b"}},
+			[]string{"/message/text"},
+		},
+		{
+			"slice element",
+			&Result{Locations: []*Location{{Id: 1}, {Id: 2}}},
+			&Result{Locations: []*Location{{Id: 1}, {Id: 3}}},
+			[]string{"/locations/1/id"},
+		},
+		{
+			"escaped map key",
+			&Result{PartialFingerprints: map[string]string{"a/b": "1"}},
+			&Result{PartialFingerprints: map[string]string{"a/b": "2"}},
+			[]string{"/partialFingerprints/a~1b"},
+		},
+		{"different types", &Result{}, &Message{}, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Result
+		want bool
+	}{
+		{"same", &Result{RuleId: "R"}, &Result{RuleId: "R"}, true},
+		{"nil and empty", nil, &Result{}, true},
+		{"different", &Result{RuleId: "R"}, &Result{RuleId: "S"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+package sarif
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expr is a compiled filter expression over results, such as
+//
+//	level == "error" && ruleId =~ "^G10" && !suppressed && uri glob "internal/**"
+//
+// An expression combines comparisons with &&, || and !, and parentheses. A
+// comparison has a field on the left and a literal on the right. The fields
+// are:
+//
+//	ruleId, ruleName, level, kind, baselineState, message, guid,
+//	correlationGuid   strings
+//	uri, tags, taxa   lists of strings
+//	rank              number
+//	suppressed        bool
+//
+// level is the effective level of the result, message its plain text and uri
+// the URIs of its locations as matched by URIGlob. String fields support ==,
+// !=, =~ and !~ (regular expression match), glob (as URIGlob) and contains
+// (substring, or membership for lists). A comparison against a list is
+// satisfied when any element satisfies it, except for != and !~ which require
+// that no element matches.
+// Numbers support ==, !=, <, <=, > and >=. A bool field may appear on its own
+// or be compared to true or false with == and !=.
+type Expr struct {
+	src  string
+	root exprNode
+}
+
+// ExprError describes a syntax or type error in a filter expression.
+type ExprError struct {
+	Pos int // 1-based column, counted in runes
+	Msg string
+}
+
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos, e.Msg)
+}
+
+// ParseExpr compiles a filter expression. Errors are of type *ExprError.
+func ParseExpr(src string) (*Expr, error) {
+	p := &exprParser{lex: exprLexer{src: src}}
+	p.next()
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// MustParseExpr is like ParseExpr but panics if src cannot be parsed.
+func MustParseExpr(src string) *Expr {
+	e, err := ParseExpr(src)
+	if err != nil {
+		panic(`sarif: ParseExpr(` + strconv.Quote(src) + `): ` + err.Error())
+	}
+	return e
+}
+
+// String returns the source text of e.
+func (e *Expr) String() string { return e.src }
+
+// Match reports whether result, which belongs to run, satisfies e.
+func (e *Expr) Match(run *Run, result *Result) bool {
+	return e.root.eval(run, result)
+}
+
+// Predicate returns e as a Predicate for use in a Query.
+func (e *Expr) Predicate() Predicate {
+	return e.Match
+}
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	listField
+	numberField
+	boolField
+)
+
+type exprField struct {
+	typ     fieldType
+	strings func(*Run, *Result) []string
+	number  func(*Run, *Result) float64
+	boolean func(*Run, *Result) bool
+}
+
+func single(f func(*Run, *Result) string) exprField {
+	return exprField{typ: stringField, strings: func(run *Run, r *Result) []string {
+		return []string{f(run, r)}
+	}}
+}
+
+var exprFields = map[string]exprField{
+	"ruleId": single(func(run *Run, r *Result) string { return run.ruleID(r) }),
+	"ruleName": single(func(run *Run, r *Result) string {
+		if rule := run.rule(r); rule != nil {
+			return rule.Name
+		}
+		return ""
+	}),
+	"level":         single(func(run *Run, r *Result) string { return run.EffectiveLevel(r) }),
+	"kind":          single(func(run *Run, r *Result) string { return withDefault(r.Kind, "fail") }),
+	"baselineState": single(func(run *Run, r *Result) string { return r.BaselineState }),
+	"message": single(func(run *Run, r *Result) string {
+		if r.Message == nil {
+			return ""
+		}
+		return r.Message.Text
+	}),
+	"guid":            single(func(run *Run, r *Result) string { return r.Guid }),
+	"correlationGuid": single(func(run *Run, r *Result) string { return r.CorrelationGuid }),
+	"uri":             {typ: listField, strings: (*Run).resultURIs},
+	"tags": {typ: listField, strings: func(run *Run, r *Result) []string {
+		if r.Properties == nil {
+			return nil
+		}
+		return r.Properties.Tags
+	}},
+	"taxa": {typ: listField, strings: func(run *Run, r *Result) []string {
+		var ids []string
+		for _, t := range r.Taxa {
+			if t != nil {
+				ids = append(ids, t.Id)
+			}
+		}
+		return ids
+	}},
+	"rank":       {typ: numberField, number: func(run *Run, r *Result) float64 { return r.Rank }},
+	"suppressed": {typ: boolField, boolean: func(run *Run, r *Result) bool { return r.IsSuppressed() }},
+}
+
+type exprNode interface {
+	eval(run *Run, r *Result) bool
+}
+
+type andNode struct{ x, y exprNode }
+
+func (n *andNode) eval(run *Run, r *Result) bool { return n.x.eval(run, r) && n.y.eval(run, r) }
+
+type orNode struct{ x, y exprNode }
+
+func (n *orNode) eval(run *Run, r *Result) bool { return n.x.eval(run, r) || n.y.eval(run, r) }
+
+type notNode struct{ x exprNode }
+
+func (n *notNode) eval(run *Run, r *Result) bool { return !n.x.eval(run, r) }
+
+type boolNode struct {
+	field exprField
+	want  bool
+}
+
+func (n *boolNode) eval(run *Run, r *Result) bool { return n.field.boolean(run, r) == n.want }
+
+type stringNode struct {
+	field  exprField
+	match  func(string) bool
+	negate bool
+}
+
+func (n *stringNode) eval(run *Run, r *Result) bool {
+	for _, s := range n.field.strings(run, r) {
+		if n.match(s) {
+			return !n.negate
+		}
+	}
+	return n.negate
+}
+
+type numberNode struct {
+	field   exprField
+	compare func(float64) bool
+}
+
+func (n *numberNode) eval(run *Run, r *Result) bool { return n.compare(n.field.number(run, r)) }
+
+type constNode bool
+
+func (n constNode) eval(*Run, *Result) bool { return bool(n) }
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string // the literal value for strings, the source text otherwise
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+type exprLexer struct {
+	src string
+	off int
+}
+
+// column converts a byte offset into a 1-based rune column.
+func (l *exprLexer) column(off int) int {
+	return utf8.RuneCountInString(l.src[:off]) + 1
+}
+
+func (l *exprLexer) next() (token, error) {
+	for l.off < len(l.src) && (l.src[l.off] == ' ' || l.src[l.off] == '\t' || l.src[l.off] == '\n' || l.src[l.off] == '\r') {
+		l.off++
+	}
+	start := l.off
+	if l.off >= len(l.src) {
+		return token{kind: tokEOF, pos: l.column(start)}, nil
+	}
+	rest := l.src[l.off:]
+	for _, op := range []string{"&&", "||", "==", "!=", "=~", "!~", "<=", ">=", "<", ">", "!"} {
+		if strings.HasPrefix(rest, op) {
+			l.off += len(op)
+			return token{kind: tokOp, text: op, pos: l.column(start)}, nil
+		}
+	}
+	c := rest[0]
+	switch {
+	case c == '(':
+		l.off++
+		return token{kind: tokLParen, text: "(", pos: l.column(start)}, nil
+	case c == ')':
+		l.off++
+		return token{kind: tokRParen, text: ")", pos: l.column(start)}, nil
+	case c == '"' || c == '`':
+		end := 1
+		for end < len(rest) && rest[end] != c {
+			if c == '"' && rest[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(rest) {
+			return token{}, &ExprError{Pos: l.column(start), Msg: "unterminated string"}
+		}
+		s, err := strconv.Unquote(rest[:end+1])
+		if err != nil {
+			return token{}, &ExprError{Pos: l.column(start), Msg: "invalid string " + rest[:end+1]}
+		}
+		l.off += end + 1
+		return token{kind: tokString, text: s, pos: l.column(start)}, nil
+	case c == '-' || c == '.' || c >= '0' && c <= '9':
+		end := 1
+		for end < len(rest) && (rest[end] == '.' || rest[end] >= '0' && rest[end] <= '9') {
+			end++
+		}
+		l.off += end
+		return token{kind: tokNumber, text: rest[:end], pos: l.column(start)}, nil
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	if unicode.IsLetter(r) || r == '_' {
+		end := 0
+		for end < len(rest) {
+			r, n := utf8.DecodeRuneInString(rest[end:])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+				break
+			}
+			end += n
+		}
+		l.off += end
+		return token{kind: tokIdent, text: rest[:end], pos: l.column(start)}, nil
+	}
+	return token{}, &ExprError{Pos: l.column(start), Msg: fmt.Sprintf("unexpected character %q", r)}
+}
+
+type exprParser struct {
+	lex exprLexer
+	tok token
+	err error
+}
+
+func (p *exprParser) next() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return &ExprError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	x, err := p.parseAnd()
+	for err == nil && p.tok.kind == tokOp && p.tok.text == "||" {
+		p.next()
+		var y exprNode
+		if y, err = p.parseAnd(); err == nil {
+			x = &orNode{x, y}
+		}
+	}
+	return x, err
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	x, err := p.parseUnary()
+	for err == nil && p.tok.kind == tokOp && p.tok.text == "&&" {
+		p.next()
+		var y exprNode
+		if y, err = p.parseUnary(); err == nil {
+			x = &andNode{x, y}
+		}
+	}
+	return x, err
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch {
+	case p.tok.kind == tokOp && p.tok.text == "!":
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{x}, nil
+	case p.tok.kind == tokLParen:
+		p.next()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ) but found %s", p.tok)
+		}
+		p.next()
+		return x, nil
+	case p.tok.kind == tokIdent && (p.tok.text == "true" || p.tok.text == "false"):
+		b := p.tok.text == "true"
+		p.next()
+		return constNode(b), nil
+	case p.tok.kind == tokIdent:
+		return p.parseComparison()
+	}
+	return nil, p.errorf("expected field name but found %s", p.tok)
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	name := p.tok
+	field, ok := exprFields[name.text]
+	if !ok {
+		return nil, p.errorf("unknown field %q", name.text)
+	}
+	p.next()
+	if p.err != nil {
+		return nil, p.err
+	}
+	op := p.tok
+	isOp := op.kind == tokOp && op.text != "&&" && op.text != "||" && op.text != "!" ||
+		op.kind == tokIdent && (op.text == "glob" || op.text == "contains")
+	if !isOp {
+		if field.typ == boolField {
+			return &boolNode{field: field, want: true}, nil
+		}
+		return nil, p.errorf("expected operator after %s but found %s", name.text, op)
+	}
+	p.next()
+	if p.err != nil {
+		return nil, p.err
+	}
+	lit := p.tok
+	node, err := p.compare(name.text, field, op, lit)
+	if err != nil {
+		return nil, err
+	}
+	p.next()
+	return node, p.err
+}
+
+func (p *exprParser) compare(name string, field exprField, op, lit token) (exprNode, error) {
+	opError := func() error {
+		return &ExprError{Pos: op.pos, Msg: fmt.Sprintf("operator %s is not defined on %s", op.text, name)}
+	}
+	switch field.typ {
+	case boolField:
+		if lit.kind != tokIdent || lit.text != "true" && lit.text != "false" {
+			return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("expected true or false but found %s", lit)}
+		}
+		want := lit.text == "true"
+		switch op.text {
+		case "==":
+		case "!=":
+			want = !want
+		default:
+			return nil, opError()
+		}
+		return &boolNode{field: field, want: want}, nil
+
+	case numberField:
+		if lit.kind != tokNumber {
+			return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("expected number but found %s", lit)}
+		}
+		n, err := strconv.ParseFloat(lit.text, 64)
+		if err != nil {
+			return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("invalid number %s", lit.text)}
+		}
+		var cmp func(float64) bool
+		switch op.text {
+		case "==":
+			cmp = func(v float64) bool { return v == n }
+		case "!=":
+			cmp = func(v float64) bool { return v != n }
+		case "<":
+			cmp = func(v float64) bool { return v < n }
+		case "<=":
+			cmp = func(v float64) bool { return v <= n }
+		case ">":
+			cmp = func(v float64) bool { return v > n }
+		case ">=":
+			cmp = func(v float64) bool { return v >= n }
+		default:
+			return nil, opError()
+		}
+		return &numberNode{field: field, compare: cmp}, nil
+	}
+
+	if lit.kind != tokString {
+		return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("expected string but found %s", lit)}
+	}
+	s := lit.text
+	node := &stringNode{field: field}
+	switch op.text {
+	case "==", "!=":
+		node.match = func(v string) bool { return v == s }
+		node.negate = op.text == "!="
+	case "=~", "!~":
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("invalid regular expression: %v", err)}
+		}
+		node.match = re.MatchString
+		node.negate = op.text == "!~"
+	case "glob":
+		if _, err := path.Match(s, ""); err != nil {
+			return nil, &ExprError{Pos: lit.pos, Msg: fmt.Sprintf("invalid glob pattern: %v", err)}
+		}
+		node.match = func(v string) bool { return matchGlob(s, v) }
+	case "contains":
+		node.match = func(v string) bool { return strings.Contains(v, s) }
+		if field.typ == listField {
+			node.match = func(v string) bool { return v == s }
+		}
+	default:
+		return nil, opError()
+	}
+	return node, nil
+}
@@ -0,0 +1,102 @@
+package sarif
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExprMatch(t *testing.T) {
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{{Id: "G101", Name: "HardcodedCredentials"}}}}}
+	result := &Result{
+		RuleId:    "G101",
+		RuleIndex: 0,
+		Level:     "error",
+		Rank:      75,
+		Message:   &Message{Text: "Potential hardcoded credentials"},
+		Locations: []*Location{
+			{PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: "internal/auth/token.go", Index: -1}}},
+			{PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: "cmd/main.go", Index: -1}}},
+		},
+		Properties: &PropertyBag{Tags: []string{"security", "CWE-798"}},
+	}
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{`level == "error"`, true},
+		{`level != "error"`, false},
+		{`ruleId =~ "^G10"`, true},
+		{`ruleId !~ "^G10"`, false},
+		{`ruleName == "HardcodedCredentials"`, true},
+		{`kind == "fail"`, true},
+		{`message contains "hardcoded"`, true},
+		{`uri glob "internal/**"`, true},
+		{`uri glob "pkg/**"`, false},
+		{`uri == "cmd/main.go"`, true},
+		{`uri != "cmd/main.go"`, false},
+		{`uri != "pkg/x.go"`, true},
+		{`tags contains "security"`, true},
+		{`rank >= 75 && rank < 80`, true},
+		{`rank > 75`, false},
+		{`suppressed`, false},
+		{`!suppressed`, true},
+		{`suppressed == false`, true},
+		{`level == "note" || ruleId == "G101"`, true},
+		{`!(level == "error" && rank < 50)`, true},
+		{`level == "error" && ruleId =~ "^G10" && !suppressed && uri glob "internal/**"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := ParseExpr(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.Match(run, result); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+			if e.String() != tt.src {
+				t.Errorf("String() = %q, want %q", e.String(), tt.src)
+			}
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+	}{
+		{`level ==`, 9},
+		{`level == "error`, 10},
+		{`nosuch == "x"`, 1},
+		{`rank == "x"`, 9},
+		{`rank =~ 5`, 6},
+		{`level < "x"`, 7},
+		{`suppressed == 1`, 15},
+		{`ruleId =~ "("`, 11},
+		{`(level == "error"`, 18},
+		{`level == "error" )`, 18},
+		{`level == "error" # x`, 18},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := ParseExpr(tt.src)
+			var exprErr *ExprError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("ParseExpr() error = %v, want an *ExprError", err)
+			}
+			if exprErr.Pos != tt.pos {
+				t.Errorf("error at column %d, want %d: %v", exprErr.Pos, tt.pos, err)
+			}
+		})
+	}
+}
+
+func TestMustParseExprPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseExpr did not panic")
+		}
+	}()
+	MustParseExpr("level ==")
+}
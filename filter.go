@@ -0,0 +1,208 @@
+package sarif
+
+import "strings"
+
+// Filter returns a copy of log whose runs contain only the results matching
+// q. The copy is pruned of the driver rules and artifacts that are no longer
+// referenced, and every index into Tool.Driver.Rules and Run.Artifacts is
+// rewritten to match. log itself is not modified.
+func Filter(log *SARIF, q *Query) *SARIF {
+	filtered := &SARIF{
+		InlineExternalProperties: cloneSlice(log.InlineExternalProperties),
+		Properties:               log.Properties.Clone(),
+		Schema:                   log.Schema,
+		Version:                  log.Version,
+	}
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		// clone a shallow copy without the results, which are cloned
+		// selectively below, so that log is never written to
+		shallow := *run
+		shallow.Results = nil
+		clone := shallow.Clone()
+		clone.Results = []*Result{}
+		for _, result := range run.Results {
+			if result != nil && q.Match(run, result) {
+				clone.Results = append(clone.Results, result.Clone())
+			}
+		}
+		clone.pruneRules()
+		clone.pruneArtifacts()
+		filtered.Runs = append(filtered.Runs, clone)
+	}
+	return filtered
+}
+
+// pruneRules removes the driver rules that nothing in run refers to, either
+// directly or through the relationships of a rule that is kept, and renumbers
+// the references to the remaining ones.
+func (run *Run) pruneRules() {
+	if run.Tool == nil || run.Tool.Driver == nil || len(run.Tool.Driver.Rules) == 0 {
+		return
+	}
+	rules := run.Tool.Driver.Rules
+	type reference struct {
+		index *int
+		rule  int
+	}
+	var refs []reference
+	used := make([]bool, len(rules))
+	var kept []int
+
+	// refer records that index refers to rule i, or to no rule if i is -1,
+	// and keeps the rule
+	refer := func(index *int, i int) {
+		refs = append(refs, reference{index, i})
+		if i >= 0 && !used[i] {
+			used[i] = true
+			kept = append(kept, i)
+		}
+	}
+	referDescriptor := func(ref *ReportingDescriptorReference) {
+		if ref != nil && ref.ToolComponent == nil {
+			refer(&ref.Index, run.driverRuleIndex(ref, true))
+		}
+	}
+
+	for _, result := range run.Results {
+		if r := result.Rule; r != nil && r.ToolComponent != nil {
+			// the rule and the result's index belong to an extension
+			continue
+		}
+		i := run.resultRuleIndex(result)
+		refer(&result.RuleIndex, i)
+		if result.Rule != nil {
+			refer(&result.Rule.Index, i)
+		}
+	}
+	Inspect(run, func(c *Cursor) bool {
+		switch n := c.Node().(type) {
+		case *Result, *ToolComponent:
+			// results are handled above, rule relationships below
+			return false
+		case *ConfigurationOverride:
+			if strings.Contains(c.Pointer(), "/ruleConfigurationOverrides/") {
+				referDescriptor(n.Descriptor)
+			}
+		case *Notification:
+			referDescriptor(n.AssociatedRule)
+		}
+		return true
+	})
+	// a kept rule keeps the rules it is related to; kept grows as they are
+	// found, so that each kept rule is visited once
+	for k := 0; k < len(kept); k++ {
+		rule := rules[kept[k]]
+		for _, rel := range rule.Relationships {
+			if rel != nil {
+				referDescriptor(rel.Target)
+			}
+		}
+	}
+
+	renumber := make([]int, len(rules))
+	remaining := make([]*ReportingDescriptor, 0, len(kept))
+	for i, rule := range rules {
+		if used[i] {
+			renumber[i] = len(remaining)
+			remaining = append(remaining, rule)
+		}
+	}
+	for _, ref := range refs {
+		if ref.rule >= 0 {
+			*ref.index = renumber[ref.rule]
+		} else {
+			*ref.index = -1
+		}
+	}
+	run.Tool.Driver.Rules = remaining
+}
+
+// pruneArtifacts removes the artifacts that nothing in run refers to and
+// renumbers the references to the remaining ones.
+func (run *Run) pruneArtifacts() {
+	if len(run.Artifacts) == 0 {
+		return
+	}
+	artifacts := run.Artifacts
+	used := make([]bool, len(artifacts))
+	var refs []*int
+	Inspect(run, func(c *Cursor) bool {
+		loc, ok := c.Node().(*ArtifactLocation)
+		if !ok {
+			return true
+		}
+		if _, ok := c.Parent().(*Artifact); ok && strings.HasPrefix(c.Pointer(), "/artifacts/") {
+			// an artifact's own location is not a reference to it
+			return false
+		}
+		// a location may refer to its artifact by uri alone, in which case
+		// the index is pointed at the artifact
+		i := run.artifactIndex(loc)
+		if i < 0 {
+			return false
+		}
+		loc.Index = i
+		used[i] = true
+		refs = append(refs, &loc.Index)
+		return false
+	})
+	// a kept artifact keeps its ancestors
+	for i := range artifacts {
+		for j := i; used[j]; {
+			p := run.parentIndex(j)
+			if p < 0 || used[p] {
+				break
+			}
+			used[p] = true
+			j = p
+		}
+	}
+
+	renumber := make([]int, len(artifacts))
+	kept := make([]*Artifact, 0, len(artifacts))
+	for i, a := range artifacts {
+		if used[i] {
+			renumber[i] = len(kept)
+			kept = append(kept, a)
+		}
+	}
+	for _, ref := range refs {
+		*ref = renumber[*ref]
+	}
+	for i, a := range artifacts {
+		if !used[i] {
+			continue
+		}
+		if p := run.parentIndex(i); p >= 0 && used[p] {
+			a.ParentIndex = renumber[p]
+		} else {
+			a.ParentIndex = -1
+		}
+		if a.Location != nil && a.Location.Index == i {
+			a.Location.Index = renumber[i]
+		}
+	}
+	run.Artifacts = kept
+}
+
+// parentIndex returns the index of the artifact that contains run.Artifacts[i],
+// or -1. An absent parentIndex decodes as 0, so 0 is only trusted when the
+// first artifact has a location that contains that of the artifact.
+func (run *Run) parentIndex(i int) int {
+	a := run.Artifacts[i]
+	p := a.ParentIndex
+	if p < 0 || p >= len(run.Artifacts) || p == i || run.Artifacts[p] == nil {
+		return -1
+	}
+	if p == 0 {
+		parent, child := run.Artifacts[0].Location, a.Location
+		if parent == nil || child == nil || parent.Uri == "" || parent.UriBaseId != child.UriBaseId ||
+			!strings.HasPrefix(child.Uri, parent.Uri) {
+			return -1
+		}
+	}
+	return p
+}
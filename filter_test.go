@@ -0,0 +1,179 @@
+package sarif
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const filterTestLog = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "t", "rules": [
+      {"id": "R0"},
+      {"id": "R1", "relationships": [{"target": {"id": "R3", "index": 3}, "kinds": ["superset"]}]},
+      {"id": "R2"},
+      {"id": "R3"}
+    ]}},
+    "artifacts": [
+      {"location": {"uri": "dir/"}},
+      {"location": {"uri": "dir/a.go"}, "parentIndex": 0},
+      {"location": {"uri": "b.go"}},
+      {"location": {"uri": "c.go"}}
+    ],
+    "results": [
+      {"ruleId": "R0", "ruleIndex": 0, "message": {"text": "zero"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "b.go", "index": 2}}}]},
+      {"ruleId": "R1", "ruleIndex": 1, "message": {"text": "one"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "dir/a.go", "index": 1}}}]},
+      {"ruleId": "R2", "message": {"text": "two"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "c.go"}}}]}
+    ]
+  }]
+}`
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *Query
+		// the ids of the kept rules, the uris of the kept artifacts, and
+		// for each kept result its rule index and artifact index
+		rules     []string
+		artifacts []string
+		results   [][2]int
+	}{
+		{
+			name:      "everything",
+			q:         NewQuery(),
+			rules:     []string{"R0", "R1", "R2", "R3"},
+			artifacts: []string{"dir/", "dir/a.go", "b.go", "c.go"},
+			results:   [][2]int{{0, 2}, {1, 1}, {2, 3}},
+		},
+		{
+			name:      "nothing",
+			q:         NewQuery(RuleID("none")),
+			rules:     []string{},
+			artifacts: []string{},
+		},
+		{
+			name:      "related rule and parent artifact are kept",
+			q:         NewQuery(RuleID("R1")),
+			rules:     []string{"R1", "R3"},
+			artifacts: []string{"dir/", "dir/a.go"},
+			results:   [][2]int{{0, 1}},
+		},
+		{
+			name:      "references by id and uri only",
+			q:         NewQuery(RuleID("R2")),
+			rules:     []string{"R2"},
+			artifacts: []string{"c.go"},
+			results:   [][2]int{{0, 0}},
+		},
+		{
+			name:      "indexes are rewritten",
+			q:         NewQuery(Not(RuleID("R1"))),
+			rules:     []string{"R0", "R2"},
+			artifacts: []string{"b.go", "c.go"},
+			results:   [][2]int{{0, 0}, {1, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log SARIF
+			if err := json.Unmarshal([]byte(filterTestLog), &log); err != nil {
+				t.Fatal(err)
+			}
+			original := log.Clone()
+			run := Filter(&log, tt.q).Runs[0]
+			if diff := Diff(original, &log); diff != nil {
+				t.Errorf("Filter modified its input at %q", diff)
+			}
+
+			rules := []string{}
+			for _, r := range run.Tool.Driver.Rules {
+				rules = append(rules, r.Id)
+			}
+			artifacts := []string{}
+			for _, a := range run.Artifacts {
+				artifacts = append(artifacts, a.Location.Uri)
+			}
+			var results [][2]int
+			for _, r := range run.Results {
+				results = append(results, [2]int{r.RuleIndex, r.Locations[0].PhysicalLocation.ArtifactLocation.Index})
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Errorf("rules = %q, want %q", rules, tt.rules)
+			}
+			if !reflect.DeepEqual(artifacts, tt.artifacts) {
+				t.Errorf("artifacts = %q, want %q", artifacts, tt.artifacts)
+			}
+			if !reflect.DeepEqual(results, tt.results) {
+				t.Errorf("result indexes = %v, want %v", results, tt.results)
+			}
+			for _, a := range run.Artifacts {
+				if p := a.ParentIndex; p > 0 && run.Artifacts[p].Location.Uri != "dir/" {
+					t.Errorf("parent of %s = %d", a.Location.Uri, p)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterRuleReferences(t *testing.T) {
+	const data = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "t", "rules": [
+      {"id": "R0"},
+      {"id": "R1", "relationships": [{"target": {"id": "R2"}, "kinds": ["superset"]}]},
+      {"id": "R2", "relationships": [{"target": {"index": 3}, "kinds": ["superset"]}]},
+      {"id": "R3", "relationships": [{"target": {"id": "R1", "index": 1}, "kinds": ["subset"]}]}
+    ]}},
+    "results": [
+      {"ruleId": "R1/sub", "message": {"text": "descendant of R1"}},
+      {"message": {"text": "no rule"}}
+    ]
+  }]
+}`
+	var log SARIF
+	if err := json.Unmarshal([]byte(data), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := Filter(&log, NewQuery()).Runs[0]
+	var rules []string
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.Id)
+	}
+	if want := []string{"R1", "R2", "R3"}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("rules = %q, want %q", rules, want)
+	}
+	if got := []int{run.Results[0].RuleIndex, run.Results[1].RuleIndex}; !reflect.DeepEqual(got, []int{0, -1}) {
+		t.Errorf("result rule indexes = %v, want [0 -1]", got)
+	}
+	var targets []int
+	for _, r := range run.Tool.Driver.Rules {
+		targets = append(targets, r.Relationships[0].Target.Index)
+	}
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("relationship targets = %v, want %v", targets, want)
+	}
+}
+
+func TestFilterConcurrent(t *testing.T) {
+	var log SARIF
+	if err := json.Unmarshal([]byte(filterTestLog), &log); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n := len(Filter(&log, NewQuery(RuleID("R0"))).Runs[0].Results); n != 1 {
+				t.Errorf("got %d results, want 1", n)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,398 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// FixFromDiff computes a minimal edit script that turns before into after and
+// returns it as a Fix with a single ArtifactChange for uri. Every Replacement
+// carries a DeletedRegion expressed both as line/column (columns counted in
+// UTF-16 code units, the SARIF default column kind) and as byte offset/length
+// into before. Regions refer to the unmodified artifact, do not overlap and are
+// listed in ascending order. FixFromDiff returns nil if before and after are
+// identical.
+func FixFromDiff(uri string, before, after []byte) *Fix {
+	hunks := diffLines(splitLines(before), splitLines(after))
+	if len(hunks) == 0 {
+		return nil
+	}
+	idx := newLineIndex(before)
+	replacements := make([]*Replacement, 0, len(hunks))
+	for _, h := range hunks {
+		del := before[h.delStart:h.delEnd]
+		ins := after[h.insStart:h.insEnd]
+		// narrow the hunk down to the bytes that actually differ
+		prefix := commonPrefix(del, ins)
+		del, ins = del[prefix:], ins[prefix:]
+		suffix := commonSuffix(del, ins)
+		del, ins = del[:len(del)-suffix], ins[:len(ins)-suffix]
+		if len(del) == 0 && len(ins) == 0 {
+			continue
+		}
+		start := h.delStart + prefix
+		replacements = append(replacements, &Replacement{
+			DeletedRegion:   idx.region(start, start+len(del)),
+			InsertedContent: newArtifactContent(ins),
+		})
+	}
+	if len(replacements) == 0 {
+		return nil
+	}
+	return &Fix{
+		ArtifactChanges: []*ArtifactChange{{
+			ArtifactLocation: &ArtifactLocation{Uri: uri},
+			Replacements:     replacements,
+		}},
+	}
+}
+
+// newArtifactContent wraps b as text if it is valid UTF-8 and as base64
+// encoded binary content otherwise.
+func newArtifactContent(b []byte) *ArtifactContent {
+	if len(b) == 0 {
+		return nil
+	}
+	if utf8.Valid(b) {
+		return &ArtifactContent{Text: string(b)}
+	}
+	return &ArtifactContent{Binary: base64.StdEncoding.EncodeToString(b)}
+}
+
+// textLine is a single line of an artifact, including its terminator, together
+// with its byte offset in the artifact.
+type textLine struct {
+	text   []byte
+	offset int
+}
+
+func splitLines(b []byte) []textLine {
+	var lines []textLine
+	offset := 0
+	for len(b) > 0 {
+		n := bytes.IndexByte(b, '\n') + 1
+		if n == 0 {
+			n = len(b)
+		}
+		lines = append(lines, textLine{text: b[:n], offset: offset})
+		offset += n
+		b = b[n:]
+	}
+	return lines
+}
+
+// hunk is a contiguous run of deleted and inserted lines, stored as byte
+// ranges into the before and after artifacts.
+type hunk struct {
+	delStart, delEnd int
+	insStart, insEnd int
+}
+
+// maxDiffTrace bounds the number of diagonals that diffLines records while
+// searching for an edit script, about 32 MB worth. Inputs that need more are
+// treated as a single replacement of the lines between their common prefix
+// and suffix.
+const maxDiffTrace = 1 << 22
+
+// diffLines runs the Myers shortest edit script algorithm over two sequences
+// of lines and groups the edits into hunks. Lines common to the start and end
+// of both sequences are matched up front; only the diagonals -d-1..d+1 that
+// step d can read back are kept for each step, so memory grows with the
+// square of the edit distance rather than with the length of the inputs.
+func diffLines(a, b []textLine) []hunk {
+	n, m := len(a), len(b)
+	keepA := make([]bool, n)
+	keepB := make([]bool, m)
+	prefix := 0
+	for prefix < n && prefix < m && bytes.Equal(a[prefix].text, b[prefix].text) {
+		keepA[prefix], keepB[prefix] = true, true
+		prefix++
+	}
+	suffix := 0
+	for suffix < n-prefix && suffix < m-prefix && bytes.Equal(a[n-1-suffix].text, b[m-1-suffix].text) {
+		keepA[n-1-suffix], keepB[m-1-suffix] = true, true
+		suffix++
+	}
+	myers(a[prefix:n-suffix], b[prefix:m-suffix], keepA[prefix:n-suffix], keepB[prefix:m-suffix])
+	offsetA := func(i int) int {
+		if i < n {
+			return a[i].offset
+		}
+		if n == 0 {
+			return 0
+		}
+		return a[n-1].offset + len(a[n-1].text)
+	}
+	offsetB := func(j int) int {
+		if j < m {
+			return b[j].offset
+		}
+		if m == 0 {
+			return 0
+		}
+		return b[m-1].offset + len(b[m-1].text)
+	}
+
+	var hunks []hunk
+	i, j := 0, 0
+	for i < n || j < m {
+		if i < n && j < m && keepA[i] && keepB[j] {
+			i++
+			j++
+			continue
+		}
+		si, sj := i, j
+		for i < n && !keepA[i] {
+			i++
+		}
+		for j < m && !keepB[j] {
+			j++
+		}
+		hunks = append(hunks, hunk{
+			delStart: offsetA(si), delEnd: offsetA(i),
+			insStart: offsetB(sj), insEnd: offsetB(j),
+		})
+	}
+	return hunks
+}
+
+// myers marks the lines of a and b that a shortest edit script keeps. If the
+// search would record more than maxDiffTrace diagonals, it gives up and keeps
+// none, so that all of a is replaced with all of b.
+func myers(a, b []textLine, keepA, keepB []bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return
+	}
+	max := n + m
+	// v[max+1+k] is the furthest x reached on diagonal k
+	v := make([]int, 2*max+3)
+	at := func(v []int, k int) int { return v[max+1+k] }
+	// trace[d] holds the diagonals -d-1..d+1 of v as they were before step d
+	var trace [][]int
+	recorded := 0
+	eq := func(x, y int) bool { return bytes.Equal(a[x].text, b[y].text) }
+search:
+	for d := 0; d <= max; d++ {
+		recorded += 2*d + 3
+		if recorded > maxDiffTrace {
+			return
+		}
+		trace = append(trace, append([]int(nil), v[max-d:max+d+3]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && at(v, k-1) < at(v, k+1)) {
+				x = at(v, k+1)
+			} else {
+				x = at(v, k-1) + 1
+			}
+			y := x - k
+			for x < n && y < m && eq(x, y) {
+				x++
+				y++
+			}
+			v[max+1+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// walk the trace backwards to recover which lines are kept
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		band := trace[d]
+		prev := func(k int) int { return band[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev(k-1) < prev(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			keepA[x] = true
+			keepB[y] = true
+		}
+		if d > 0 {
+			x, y = prevX, prevY
+		}
+	}
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b
+// that ends on a UTF-8 rune boundary.
+func commonPrefix(a, b []byte) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[n]) {
+		n--
+	}
+	return n
+}
+
+// commonSuffix returns the length of the longest common suffix of a and b
+// that starts on a UTF-8 rune boundary.
+func commonSuffix(a, b []byte) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	for n > 0 && !utf8.RuneStart(a[len(a)-n]) {
+		n--
+	}
+	return n
+}
+
+// lineIndex maps byte offsets of an artifact to SARIF line/column positions.
+type lineIndex struct {
+	content []byte
+	starts  []int
+}
+
+func newLineIndex(content []byte) *lineIndex {
+	starts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{content: content, starts: starts}
+}
+
+// position returns the 1-based line and UTF-16 column of the byte at offset.
+// The offset just past a trailing newline is reported as the end of the last
+// line rather than as a line that does not exist.
+func (idx *lineIndex) position(offset int) (int, int) {
+	l := sort.Search(len(idx.starts), func(i int) bool { return idx.starts[i] > offset }) - 1
+	if l > 0 && offset == len(idx.content) && idx.starts[l] == offset {
+		l--
+	}
+	col := 1
+	for _, r := range string(idx.content[idx.starts[l]:offset]) {
+		col++
+		if r >= 0x10000 {
+			// runes outside the BMP take a surrogate pair
+			col++
+		}
+	}
+	return l + 1, col
+}
+
+// region builds a Region covering the bytes [start, end).
+func (idx *lineIndex) region(start, end int) *Region {
+	startLine, startColumn := idx.position(start)
+	endLine, endColumn := idx.position(end)
+	return &Region{
+		StartLine:   startLine,
+		StartColumn: startColumn,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+		ByteOffset:  start,
+		ByteLength:  end - start,
+	}
+}
+
+// offset returns the byte offset of the 1-based line and UTF-16 column, or -1
+// if the line does not exist. As in position, the newline ending a line counts
+// as one of its columns; columns past it are clamped to the end of the line.
+func (idx *lineIndex) offset(line, column int) int {
+	if line < 1 || line > len(idx.starts) {
+		return -1
+	}
+	off := idx.starts[line-1]
+	for col := 1; col < column && off < len(idx.content); {
+		r, size := utf8.DecodeRune(idx.content[off:])
+		if r == '\n' {
+			return off + 1
+		}
+		col++
+		if r >= 0x10000 {
+			col++
+		}
+		off += size
+	}
+	return off
+}
+
+// offsets returns the byte range [start, end) that r covers. Byte offsets are
+// used when r has them, and line/column positions otherwise; an absent end
+// column stands for the end of the line, excluding its newline.
+func (idx *lineIndex) offsets(r *Region) (int, int, bool) {
+	if r.StartLine == 0 {
+		start, end := r.ByteOffset, r.ByteOffset+r.ByteLength
+		return start, end, start >= 0 && start <= end && end <= len(idx.content)
+	}
+	if r.ByteOffset > 0 || r.ByteLength > 0 {
+		start, end := r.ByteOffset, r.ByteOffset+r.ByteLength
+		if start <= end && end <= len(idx.content) {
+			return start, end, true
+		}
+	}
+	start := idx.offset(r.StartLine, r.StartColumn)
+	endLine := r.EndLine
+	if endLine == 0 {
+		endLine = r.StartLine
+	}
+	var end int
+	if r.EndColumn > 0 {
+		end = idx.offset(endLine, r.EndColumn)
+	} else if end = idx.offset(endLine, len(idx.content)+1); end > 0 && idx.content[end-1] == '\n' {
+		end--
+	}
+	return start, end, start >= 0 && start <= end
+}
+
+// applyReplacements applies the replacements of an ArtifactChange to the
+// content of the artifact they refer to.
+func applyReplacements(content []byte, replacements []*Replacement) ([]byte, error) {
+	type edit struct {
+		start, end int
+		text       []byte
+	}
+	idx := newLineIndex(content)
+	var edits []edit
+	for i, r := range replacements {
+		if r == nil || r.DeletedRegion == nil {
+			continue
+		}
+		start, end, ok := idx.offsets(r.DeletedRegion)
+		if !ok {
+			return nil, fmt.Errorf("replacement %d: deleted region is outside the artifact", i)
+		}
+		var text []byte
+		if c := r.InsertedContent; c != nil {
+			if c.Binary != "" {
+				b, err := base64.StdEncoding.DecodeString(c.Binary)
+				if err != nil {
+					return nil, fmt.Errorf("replacement %d: %w", i, err)
+				}
+				text = b
+			} else {
+				text = []byte(c.Text)
+			}
+		}
+		edits = append(edits, edit{start, end, text})
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out []byte
+	pos := 0
+	for _, e := range edits {
+		if e.start < pos {
+			return nil, errors.New("replacements overlap")
+		}
+		out = append(append(out, content[pos:e.start]...), e.text...)
+		pos = e.end
+	}
+	return append(out, content[pos:]...), nil
+}
@@ -0,0 +1,106 @@
+package sarif
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFixFromDiffRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+	}{
+		{"insert into empty", "", "a\nb\n"},
+		{"delete everything", "a\nb\n", ""},
+		{"insert line", "a\nc\n", "a\nb\nc\n"},
+		{"delete line", "a\nb\nc\n", "a\nc\n"},
+		{"change within line", "x := 1\n", "x := 2\n"},
+		{"append without trailing newline", "a\nb", "a\nb\nc"},
+		{"add trailing newline", "a", "a\n"},
+		{"crlf", "a\r\nb\r\n", "a\r\nB\r\n"},
+		{"several hunks", "1\n2\n3\n4\n5\n6\n", "1\nx\n3\n4\ny\n6\n"},
+		{"multibyte", "naïve 😀 text\n", "naive 😀 text!\n"},
+		{"invalid utf-8", "a\xff\n", "a\xfe\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fix := FixFromDiff("file.txt", []byte(tt.before), []byte(tt.after))
+			if fix == nil {
+				t.Fatal("FixFromDiff returned nil for different contents")
+			}
+			replacements := fix.ArtifactChanges[0].Replacements
+			got, err := applyReplacements([]byte(tt.before), replacements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.after {
+				t.Errorf("applying byte ranges gave %q, want %q", got, tt.after)
+			}
+
+			// the line/column form of the regions must describe the same edits
+			for _, r := range replacements {
+				r.DeletedRegion.ByteOffset, r.DeletedRegion.ByteLength = 0, 0
+			}
+			got, err = applyReplacements([]byte(tt.before), replacements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.after {
+				t.Errorf("applying line/column regions gave %q, want %q", got, tt.after)
+			}
+		})
+	}
+}
+
+func TestFixFromDiffIdentical(t *testing.T) {
+	if fix := FixFromDiff("f", []byte("same\n"), []byte("same\n")); fix != nil {
+		t.Errorf("FixFromDiff of identical contents = %+v, want nil", fix)
+	}
+}
+
+func TestFixFromDiffLargeRewrite(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines int
+	}{
+		{"within the trace bound", 500},
+		{"beyond the trace bound", 5000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after strings.Builder
+			for i := 0; i < tt.lines; i++ {
+				fmt.Fprintf(&before, "old line %d\n", i)
+				fmt.Fprintf(&after, "new line %d\n", i)
+			}
+			var stats runtime.MemStats
+			runtime.ReadMemStats(&stats)
+			allocated := stats.TotalAlloc
+			fix := FixFromDiff("f", []byte(before.String()), []byte(after.String()))
+			runtime.ReadMemStats(&stats)
+			if mb := (stats.TotalAlloc - allocated) >> 20; mb > 200 {
+				t.Errorf("FixFromDiff allocated %d MB", mb)
+			}
+			got, err := applyReplacements([]byte(before.String()), fix.ArtifactChanges[0].Replacements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, []byte(after.String())) {
+				t.Error("applying the fix did not produce the new contents")
+			}
+		})
+	}
+}
+
+func TestApplyReplacementsOverlap(t *testing.T) {
+	replacements := []*Replacement{
+		{DeletedRegion: &Region{ByteOffset: 0, ByteLength: 3}},
+		{DeletedRegion: &Region{ByteOffset: 2, ByteLength: 2}},
+	}
+	if _, err := applyReplacements([]byte("abcdef"), replacements); err == nil {
+		t.Error("applyReplacements accepted overlapping replacements")
+	}
+}
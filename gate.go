@@ -0,0 +1,211 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Gate is a quality gate: a set of thresholds that the results of a SARIF log
+// must stay within. A gate is usually decoded from JSON, e.g.
+//
+//	{
+//	  "conditions": [
+//	    {"name": "new errors", "levels": ["error"], "baselineStates": ["new"], "max": 0},
+//	    {"levels": ["warning"], "paths": ["cmd/**"], "max": 20}
+//	  ]
+//	}
+//
+// Suppressed results, as defined by Result.IsSuppressed, are ignored unless
+// IncludeSuppressed is set.
+type Gate struct {
+	Conditions        []*GateCondition `json:"conditions"`
+	IncludeSuppressed bool             `json:"includeSuppressed,omitempty"`
+}
+
+// GateCondition limits the number of results that match all of its criteria;
+// a criterion that is empty matches every result.
+type GateCondition struct {
+	// Name describes the condition in explanations; a description is
+	// derived from the criteria if it is empty.
+	Name string `json:"name,omitempty"`
+	// Levels are effective levels, as computed by Run.EffectiveLevel.
+	Levels []string `json:"levels,omitempty"`
+	// RuleIDs are rule ids, which also match their hierarchical descendants.
+	RuleIDs []string `json:"ruleIds,omitempty"`
+	// BaselineStates are baseline states such as "new".
+	BaselineStates []string `json:"baselineStates,omitempty"`
+	// Paths are glob patterns matched against the result's artifact URIs as
+	// by URIGlob.
+	Paths []string `json:"paths,omitempty"`
+	// Where is a filter expression, as parsed by ParseExpr.
+	Where string `json:"where,omitempty"`
+	// Max is the largest number of matching results that passes.
+	Max int `json:"max"`
+}
+
+// ReadGate decodes a gate from the JSON read from r.
+func ReadGate(r io.Reader) (*Gate, error) {
+	var g Gate
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("reading gate: %w", err)
+	}
+	return &g, nil
+}
+
+// GateOutcome is the outcome of evaluating a Gate.
+type GateOutcome struct {
+	Passed     bool
+	Conditions []*ConditionOutcome
+}
+
+// ConditionOutcome is the outcome of evaluating a GateCondition.
+type ConditionOutcome struct {
+	Condition *GateCondition
+	Passed    bool
+	// Matches are the results that match the condition.
+	Matches []Match
+}
+
+// Evaluate evaluates the conditions of g against the results of log. The gate
+// passes if every condition does. It returns an error if the Where
+// expression of a condition cannot be parsed.
+func (g *Gate) Evaluate(log *SARIF) (*GateOutcome, error) {
+	outcome := &GateOutcome{Passed: true}
+	for i, c := range g.Conditions {
+		if c == nil {
+			continue
+		}
+		q, err := c.query(g.IncludeSuppressed)
+		if err != nil {
+			return nil, fmt.Errorf("gate condition %d: %w", i, err)
+		}
+		matches := q.Results(log)
+		co := &ConditionOutcome{Condition: c, Passed: len(matches) <= c.Max, Matches: matches}
+		outcome.Conditions = append(outcome.Conditions, co)
+		outcome.Passed = outcome.Passed && co.Passed
+	}
+	return outcome, nil
+}
+
+func (c *GateCondition) query(includeSuppressed bool) (*Query, error) {
+	q := NewQuery()
+	if !includeSuppressed {
+		q.Where(Not(Suppressed()))
+	}
+	if len(c.Levels) > 0 {
+		q.Where(Level(c.Levels...))
+	}
+	if len(c.RuleIDs) > 0 {
+		q.Where(RuleID(c.RuleIDs...))
+	}
+	if len(c.BaselineStates) > 0 {
+		q.Where(BaselineState(c.BaselineStates...))
+	}
+	if len(c.Paths) > 0 {
+		q.Where(URIGlob(c.Paths...))
+	}
+	if c.Where != "" {
+		e, err := ParseExpr(c.Where)
+		if err != nil {
+			return nil, err
+		}
+		q.Where(e.Predicate())
+	}
+	return q, nil
+}
+
+// String describes c, by its name if it has one.
+func (c *GateCondition) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	var parts []string
+	list := func(field string, values []string) {
+		if len(values) > 0 {
+			parts = append(parts, field+" "+strings.Join(values, "|"))
+		}
+	}
+	list("level", c.Levels)
+	list("rule", c.RuleIDs)
+	list("baseline", c.BaselineStates)
+	list("path", c.Paths)
+	if c.Where != "" {
+		parts = append(parts, "where "+c.Where)
+	}
+	if len(parts) == 0 {
+		return "all results"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String explains the outcome of a condition, e.g.
+// "FAIL new errors: 3 results, at most 0 allowed".
+func (o *ConditionOutcome) String() string {
+	status := "PASS"
+	if !o.Passed {
+		status = "FAIL"
+	}
+	return fmt.Sprintf("%s %s: %d %s, at most %d allowed", status, o.Condition, len(o.Matches), plural(len(o.Matches), "result", "results"), o.Condition.Max)
+}
+
+// Explain writes an explanation of o to w: one line per condition, followed
+// for each failed condition by up to limit of the results that failed it. A
+// negative limit lists all of them.
+func (o *GateOutcome) Explain(w io.Writer, limit int) error {
+	var b strings.Builder
+	for _, co := range o.Conditions {
+		fmt.Fprintln(&b, co)
+		if co.Passed {
+			continue
+		}
+		for i, m := range co.Matches {
+			if limit >= 0 && i == limit {
+				fmt.Fprintf(&b, "    ... and %d more\n", len(co.Matches)-limit)
+				break
+			}
+			fmt.Fprintf(&b, "    %s\n", describeMatch(m))
+		}
+	}
+	if o.Passed {
+		b.WriteString("gate passed\n")
+	} else {
+		b.WriteString("gate failed\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// describeMatch describes a result as "uri:line: level rule: message".
+func describeMatch(m Match) string {
+	var b strings.Builder
+	if pl := primaryLocation(m.Result); pl != nil {
+		b.WriteString(m.Run.displayURI(pl.ArtifactLocation))
+		if pl.Region != nil && pl.Region.StartLine > 0 {
+			fmt.Fprintf(&b, ":%d", pl.Region.StartLine)
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(m.Run.EffectiveLevel(m.Result))
+	if id := m.Run.ruleID(m.Result); id != "" {
+		b.WriteString(" " + id)
+	}
+	if text, err := m.Run.FormatMessage(m.Result, false); err == nil && text != "" {
+		b.WriteString(": " + strings.SplitN(text, "\n", 2)[0])
+	}
+	return b.String()
+}
+
+// primaryLocation returns the first physical location of result that has an
+// artifact location.
+func primaryLocation(result *Result) *PhysicalLocation {
+	for _, loc := range result.Locations {
+		if loc != nil && loc.PhysicalLocation != nil && loc.PhysicalLocation.ArtifactLocation != nil {
+			return loc.PhysicalLocation
+		}
+	}
+	return nil
+}
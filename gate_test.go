@@ -0,0 +1,100 @@
+package sarif
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGateEvaluate(t *testing.T) {
+	log := readTestLog(t, "sample.sarif")
+	tests := []struct {
+		name    string
+		gate    string
+		passed  bool
+		matches []int
+		wantErr bool
+	}{
+		{"no errors", `{"conditions": [{"levels": ["error"], "max": 0}]}`, false, []int{1}, false},
+		{"a few warnings", `{"conditions": [{"levels": ["warning"], "max": 1}]}`, true, []int{1}, false},
+		{"suppressed are ignored", `{"conditions": [{"levels": ["note"], "max": 0}]}`, true, []int{0}, false},
+		{"suppressed included", `{"conditions": [{"levels": ["note"], "max": 0}], "includeSuppressed": true}`, false, []int{1}, false},
+		{"rule and path", `{"conditions": [{"ruleIds": ["G101"], "paths": ["internal/**"], "max": 0}]}`, false, []int{1}, false},
+		{"baseline", `{"conditions": [{"baselineStates": ["new"], "max": 0}]}`, true, []int{0}, false},
+		{"expression", `{"conditions": [{"where": "rank > 50", "max": 0}]}`, false, []int{1}, false},
+		{
+			"every condition counts",
+			`{"conditions": [{"levels": ["warning"], "max": 5}, {"levels": ["error"], "max": 0}]}`,
+			false, []int{1, 1}, false,
+		},
+		{"bad expression", `{"conditions": [{"where": "rank >", "max": 0}]}`, false, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ReadGate(strings.NewReader(tt.gate))
+			if err != nil {
+				t.Fatal(err)
+			}
+			outcome, err := g.Evaluate(log)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Evaluate() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if outcome.Passed != tt.passed {
+				t.Errorf("Passed = %v, want %v", outcome.Passed, tt.passed)
+			}
+			for i, co := range outcome.Conditions {
+				if len(co.Matches) != tt.matches[i] {
+					t.Errorf("condition %d matched %d results, want %d", i, len(co.Matches), tt.matches[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadGateUnknownField(t *testing.T) {
+	if _, err := ReadGate(strings.NewReader(`{"conditions": [{"level": ["error"]}]}`)); err == nil {
+		t.Error("ReadGate accepted an unknown field")
+	}
+}
+
+func TestGateConditionString(t *testing.T) {
+	tests := []struct {
+		c    GateCondition
+		want string
+	}{
+		{GateCondition{Name: "new errors", Levels: []string{"error"}}, "new errors"},
+		{GateCondition{}, "all results"},
+		{GateCondition{Levels: []string{"error", "warning"}, Paths: []string{"cmd/**"}, Where: "rank > 1"}, "level error|warning, path cmd/**, where rank > 1"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGateExplainGolden(t *testing.T) {
+	g, err := ReadGate(strings.NewReader(`{"conditions": [
+		{"name": "no errors", "levels": ["error"], "max": 0},
+		{"levels": ["warning", "error"], "max": 0},
+		{"levels": ["note"], "max": 0}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcome, err := g.Evaluate(readTestLog(t, "sample.sarif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := outcome.Explain(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "gate.txt", buf.Bytes())
+}
@@ -0,0 +1,286 @@
+//go:build ignore
+
+// This program generates the traversal, clone and comparison code for the
+// types declared in sarif.go. Run it with go generate whenever sarif.go
+// changes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldKind classifies the fields that hold other SARIF objects.
+type fieldKind int
+
+const (
+	plain   fieldKind = iota // anything that is not a SARIF object
+	pointer                  // *T
+	slice                    // []*T
+	mapping                  // map[string]*T
+)
+
+type field struct {
+	Name string
+	JSON string
+	Kind fieldKind
+	Elem string // the SARIF type referred to, for non-plain fields
+	Type string // the Go type as written in sarif.go
+}
+
+type typeDecl struct {
+	Name   string
+	Fields []field
+}
+
+// manual lists the types whose Clone and diff methods are written by hand.
+var manual = map[string]bool{
+	"PropertyBag": true,
+}
+
+// defaults lists string properties whose absence the spec defines to mean a
+// particular value, so that "" and the default compare equal.
+var defaults = map[string]string{
+	"Notification.Level":            "warning",
+	"ReportingConfiguration.Level":  "warning",
+	"Result.Kind":                   "fail",
+	"ThreadFlowLocation.Importance": "important",
+}
+
+func main() {
+	types := parseTypes("sarif.go")
+	write("walk_gen.go", genWalk(types))
+	write("clone_gen.go", genClone(types))
+	write("equal_gen.go", genEqual(types))
+}
+
+func parseTypes(filename string) []typeDecl {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	declared := make(map[string]bool)
+	var specs []*ast.TypeSpec
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if _, ok := ts.Type.(*ast.StructType); ok {
+				declared[ts.Name.Name] = true
+				specs = append(specs, ts)
+			}
+		}
+	}
+
+	var types []typeDecl
+	for _, ts := range specs {
+		td := typeDecl{Name: ts.Name.Name}
+		for _, fl := range ts.Type.(*ast.StructType).Fields.List {
+			tag := ""
+			if fl.Tag != nil {
+				raw, _ := strconv.Unquote(fl.Tag.Value)
+				tag, _, _ = strings.Cut(reflect.StructTag(raw).Get("json"), ",")
+			}
+			kind, elem := classify(fl.Type, declared)
+			for _, name := range fl.Names {
+				td.Fields = append(td.Fields, field{
+					Name: name.Name,
+					JSON: tag,
+					Kind: kind,
+					Elem: elem,
+					Type: exprString(fl.Type),
+				})
+			}
+		}
+		types = append(types, td)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+func classify(expr ast.Expr, declared map[string]bool) (fieldKind, string) {
+	starIdent := func(e ast.Expr) string {
+		if star, ok := e.(*ast.StarExpr); ok {
+			if id, ok := star.X.(*ast.Ident); ok && declared[id.Name] {
+				return id.Name
+			}
+		}
+		return ""
+	}
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		if name := starIdent(t); name != "" {
+			return pointer, name
+		}
+	case *ast.ArrayType:
+		if name := starIdent(t.Elt); name != "" {
+			return slice, name
+		}
+	case *ast.MapType:
+		if name := starIdent(t.Value); name != "" {
+			return mapping, name
+		}
+	}
+	return plain, ""
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+func write(filename string, src []byte) {
+	out, err := format.Source(src)
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", filename, err, src)
+	}
+	if err := os.WriteFile(filename, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func header(buf *bytes.Buffer, imports ...string) {
+	buf.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage sarif\n\n")
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(buf, "\t%q\n", imp)
+		}
+		buf.WriteString(")\n\n")
+	}
+}
+
+func genWalk(types []typeDecl) []byte {
+	var buf bytes.Buffer
+	header(&buf, "strconv")
+
+	buf.WriteString("// children walks the SARIF objects directly contained in node.\n")
+	buf.WriteString("func (w *walker) children(node any, ptr string) {\n\tswitch n := node.(type) {\n")
+	for _, t := range types {
+		fmt.Fprintf(&buf, "\tcase *%s:\n\t\tw.walk%s(n, ptr)\n", t.Name, t.Name)
+	}
+	buf.WriteString("\t}\n}\n\n")
+
+	for _, t := range types {
+		fmt.Fprintf(&buf, "func (w *walker) walk%s(n *%s, ptr string) {\n", t.Name, t.Name)
+		for _, f := range t.Fields {
+			ptr := strconv.Quote("/" + f.JSON)
+			switch f.Kind {
+			case pointer:
+				fmt.Fprintf(&buf, "\tif n.%[1]s != nil {\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tw.walk(n.%[1]s, n, ptr+%[2]s, func(x any) { n.%[1]s = as[*%[3]s](x) })\n", f.Name, ptr, f.Elem)
+				buf.WriteString("\t}\n")
+			case slice:
+				fmt.Fprintf(&buf, "\tfor i := range n.%s {\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tif n.%s[i] != nil {\n\t\t\ti := i\n", f.Name)
+				fmt.Fprintf(&buf, "\t\t\tw.walk(n.%[1]s[i], n, ptr+%[2]s+\"/\"+strconv.Itoa(i), func(x any) { n.%[1]s[i] = as[*%[3]s](x) })\n", f.Name, ptr, f.Elem)
+				buf.WriteString("\t\t}\n\t}\n")
+			case mapping:
+				fmt.Fprintf(&buf, "\tfor _, k := range sortedKeys(n.%s) {\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tif n.%s[k] != nil {\n\t\t\tk := k\n", f.Name)
+				fmt.Fprintf(&buf, "\t\t\tw.walk(n.%[1]s[k], n, ptr+%[2]s+\"/\"+escapePointer(k), func(x any) { n.%[1]s[k] = as[*%[3]s](x) })\n", f.Name, ptr, f.Elem)
+				buf.WriteString("\t\t}\n\t}\n")
+			}
+		}
+		buf.WriteString("}\n\n")
+	}
+	return buf.Bytes()
+}
+
+func genClone(types []typeDecl) []byte {
+	var buf bytes.Buffer
+	header(&buf)
+	for _, t := range types {
+		if manual[t.Name] {
+			continue
+		}
+		fmt.Fprintf(&buf, "// Clone returns a deep copy of strct.\nfunc (strct *%s) Clone() *%s {\n", t.Name, t.Name)
+		buf.WriteString("\tif strct == nil {\n\t\treturn nil\n\t}\n\tc := *strct\n")
+		for _, f := range t.Fields {
+			switch {
+			case f.Kind == pointer:
+				fmt.Fprintf(&buf, "\tc.%[1]s = strct.%[1]s.Clone()\n", f.Name)
+			case f.Kind == slice:
+				fmt.Fprintf(&buf, "\tc.%[1]s = cloneSlice(strct.%[1]s)\n", f.Name)
+			case f.Kind == mapping:
+				fmt.Fprintf(&buf, "\tc.%[1]s = cloneMap(strct.%[1]s)\n", f.Name)
+			case f.Type == "[]string":
+				fmt.Fprintf(&buf, "\tc.%[1]s = cloneStrings(strct.%[1]s)\n", f.Name)
+			case f.Type == "map[string]string":
+				fmt.Fprintf(&buf, "\tc.%[1]s = cloneStringMap(strct.%[1]s)\n", f.Name)
+			case strings.HasPrefix(f.Type, "[]") || strings.HasPrefix(f.Type, "map["):
+				log.Fatalf("%s.%s: no clone strategy for %s", t.Name, f.Name, f.Type)
+			}
+		}
+		buf.WriteString("\treturn &c\n}\n\n")
+	}
+	return buf.Bytes()
+}
+
+func genEqual(types []typeDecl) []byte {
+	var buf bytes.Buffer
+	header(&buf, "strconv")
+
+	buf.WriteString("// diffAny dispatches to the diff method for the dynamic type of a.\n")
+	buf.WriteString("func (d *differ) diffAny(a, b any, ptr string) {\n\tswitch a := a.(type) {\n")
+	for _, t := range types {
+		fmt.Fprintf(&buf, "\tcase *%[1]s:\n\t\tif b, ok := b.(*%[1]s); ok {\n\t\t\td.diff%[1]s(a, b, ptr)\n\t\t\treturn\n\t\t}\n", t.Name)
+	}
+	buf.WriteString("\t}\n\td.report(ptr)\n}\n\n")
+
+	for _, t := range types {
+		fmt.Fprintf(&buf, "// Equal reports whether strct and other are semantically equal, as defined\n// by Diff.\nfunc (strct *%[1]s) Equal(other *%[1]s) bool {\n", t.Name)
+		fmt.Fprintf(&buf, "\td := &differ{limit: 1}\n\td.diff%s(strct, other, \"\")\n\treturn len(d.paths) == 0\n}\n\n", t.Name)
+		if manual[t.Name] {
+			continue
+		}
+		fmt.Fprintf(&buf, "func (d *differ) diff%[1]s(a, b *%[1]s, ptr string) {\n", t.Name)
+		buf.WriteString("\tif a == b || d.done() {\n\t\treturn\n\t}\n")
+		fmt.Fprintf(&buf, "\tif a == nil {\n\t\ta = &%[1]s{}\n\t}\n\tif b == nil {\n\t\tb = &%[1]s{}\n\t}\n", t.Name)
+		for _, f := range t.Fields {
+			ptr := strconv.Quote("/" + f.JSON)
+			switch {
+			case f.Kind == pointer:
+				fmt.Fprintf(&buf, "\td.diff%[1]s(a.%[2]s, b.%[2]s, ptr+%[3]s)\n", f.Elem, f.Name, ptr)
+			case f.Kind == slice:
+				fmt.Fprintf(&buf, "\tfor i := 0; i < len(a.%[1]s) || i < len(b.%[1]s); i++ {\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tif i >= len(a.%[1]s) || i >= len(b.%[1]s) {\n\t\t\td.report(ptr + %[2]s + \"/\" + strconv.Itoa(i))\n\t\t\tcontinue\n\t\t}\n", f.Name, ptr)
+				fmt.Fprintf(&buf, "\t\td.diff%[1]s(a.%[2]s[i], b.%[2]s[i], ptr+%[3]s+\"/\"+strconv.Itoa(i))\n\t}\n", f.Elem, f.Name, ptr)
+			case f.Kind == mapping:
+				fmt.Fprintf(&buf, "\tfor _, k := range unionKeys(a.%[1]s, b.%[1]s) {\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tav, aok := a.%[1]s[k]\n\t\tbv, bok := b.%[1]s[k]\n", f.Name)
+				fmt.Fprintf(&buf, "\t\tif aok != bok {\n\t\t\td.report(ptr + %[1]s + \"/\" + escapePointer(k))\n\t\t\tcontinue\n\t\t}\n", ptr)
+				fmt.Fprintf(&buf, "\t\td.diff%[1]s(av, bv, ptr+%[2]s+\"/\"+escapePointer(k))\n\t}\n", f.Elem, ptr)
+			case f.Type == "[]string":
+				fmt.Fprintf(&buf, "\td.diffStrings(a.%[1]s, b.%[1]s, ptr+%[2]s)\n", f.Name, ptr)
+			case f.Type == "map[string]string":
+				fmt.Fprintf(&buf, "\td.diffStringMap(a.%[1]s, b.%[1]s, ptr+%[2]s)\n", f.Name, ptr)
+			case f.Type == "string" && defaults[t.Name+"."+f.Name] != "":
+				fmt.Fprintf(&buf, "\tdiffValue(d, withDefault(a.%[1]s, %[3]q), withDefault(b.%[1]s, %[3]q), ptr+%[2]s)\n", f.Name, ptr, defaults[t.Name+"."+f.Name])
+			case f.Type == "string" || f.Type == "int" || f.Type == "bool" || f.Type == "float64":
+				fmt.Fprintf(&buf, "\tdiffValue(d, a.%[1]s, b.%[1]s, ptr+%[2]s)\n", f.Name, ptr)
+			default:
+				log.Fatalf("%s.%s: no diff strategy for %s", t.Name, f.Name, f.Type)
+			}
+		}
+		buf.WriteString("}\n\n")
+	}
+	return buf.Bytes()
+}
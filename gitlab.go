@@ -0,0 +1,497 @@
+package sarif
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// GitLabSASTVersion is the version of the GitLab SAST report schema that
+// WriteGitLabSAST writes and ValidateGitLabSAST checks against.
+const GitLabSASTVersion = "15.0.7"
+
+// The schema is a vendored copy of GitLab's dist/sast-report-format.json of
+// GitLabSASTVersion, from the security-report-schemas project.
+//
+//go:embed schemas/gitlab-sast-report-format.json
+var gitLabSASTSchemaData []byte
+
+var gitLabSASTSchema = func() *jsonSchema {
+	s, err := parseJSONSchema(gitLabSASTSchemaData)
+	if err != nil {
+		panic(fmt.Sprintf("schemas/gitlab-sast-report-format.json: %v", err))
+	}
+	return s
+}()
+
+// GitLabTool describes an analyzer or scanner of a GitLab SAST report.
+type GitLabTool struct {
+	// ID is made of lowercase letters, digits, hyphens and underscores.
+	ID      string
+	Name    string
+	Version string
+	Vendor  string
+	URL     string
+}
+
+// GitLabSASTOptions configures WriteGitLabSAST.
+type GitLabSASTOptions struct {
+	// Analyzer is the analyzer of the report, the program that produced it;
+	// the scanner if nil. Its name and vendor default to its id, and its
+	// version to "unknown".
+	Analyzer *GitLabTool
+	// Time is the start and end time of the scan if the runs record no
+	// invocation times; the current time if zero.
+	Time time.Time
+}
+
+type gitLabReport struct {
+	Version         string                 `json:"version"`
+	Schema          string                 `json:"schema"`
+	Scan            gitLabScan             `json:"scan"`
+	Vulnerabilities []*gitLabVulnerability `json:"vulnerabilities"`
+}
+
+type gitLabScan struct {
+	Analyzer  *gitLabTool      `json:"analyzer"`
+	Scanner   *gitLabTool      `json:"scanner"`
+	Type      string           `json:"type"`
+	StartTime string           `json:"start_time"`
+	EndTime   string           `json:"end_time"`
+	Status    string           `json:"status"`
+	Messages  []*gitLabMessage `json:"messages,omitempty"`
+}
+
+type gitLabTool struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	URL     string       `json:"url,omitempty"`
+	Vendor  gitLabVendor `json:"vendor"`
+	Version string       `json:"version"`
+}
+
+type gitLabVendor struct {
+	Name string `json:"name"`
+}
+
+type gitLabMessage struct {
+	Level string `json:"level"`
+	Value string `json:"value"`
+}
+
+type gitLabVulnerability struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Severity    string              `json:"severity,omitempty"`
+	Solution    string              `json:"solution,omitempty"`
+	Identifiers []*gitLabIdentifier `json:"identifiers"`
+	Location    gitLabLocation      `json:"location"`
+}
+
+type gitLabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type gitLabLocation struct {
+	File      string `json:"file,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Class     string `json:"class,omitempty"`
+	Method    string `json:"method,omitempty"`
+}
+
+// maxGitLabIdentifiers is the number of identifiers the schema allows a
+// vulnerability.
+const maxGitLabIdentifiers = 20
+
+// gitLabTimeLayout is the layout of the times of a GitLab report, in UTC.
+const gitLabTimeLayout = "2006-01-02T15:04:05"
+
+// gitLabMessageLevels maps notification levels onto the levels of scan
+// messages.
+var gitLabMessageLevels = map[string]string{
+	"error":   "fatal",
+	"warning": "warn",
+	"note":    "info",
+	"none":    "info",
+}
+
+// WriteGitLabSAST writes the results of log to w as a GitLab SAST report,
+// gl-sast-report.json, for GitLab's security dashboards. The scanner of the
+// report is the driver of the first run. Each result that is not suppressed,
+// whose kind is "fail" and that has a location in an artifact becomes a
+// vulnerability; the others are skipped, since a vulnerability needs a file:
+//
+//   - its identifiers are its rule id, and the taxa it references or its rule
+//     relates to, with CWE and OWASP Top 10 taxa identified the way GitLab
+//     expects them, up to the 20 identifiers the schema allows;
+//   - its severity derives from the "security-severity" property of its rule,
+//     a CVSS score, or else from its effective level;
+//   - its name and solution come from the short description and help of its
+//     rule, and its location from its first location in an artifact.
+//
+// The start and end time of the scan come from the invocations of the runs,
+// whose tool execution notifications become scan messages. The report is
+// validated against the schema before it is written; if it does not conform,
+// WriteGitLabSAST writes nothing and returns an error that wraps
+// ErrSchemaViolation. opts may be nil.
+func WriteGitLabSAST(w io.Writer, log *SARIF, opts *GitLabSASTOptions) error {
+	if opts == nil {
+		opts = &GitLabSASTOptions{}
+	}
+	report := &gitLabReport{
+		Version:         GitLabSASTVersion,
+		Schema:          "https://gitlab.com/gitlab-org/security-products/security-report-schemas/-/raw/v" + GitLabSASTVersion + "/dist/sast-report-format.json",
+		Vulnerabilities: []*gitLabVulnerability{},
+	}
+	report.Scan = gitLabScanOf(log, opts)
+	seen := make(map[string]int)
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		for _, result := range run.Results {
+			if result == nil || result.IsSuppressed() || withDefault(result.Kind, "fail") != "fail" || primaryLocation(result) == nil {
+				continue
+			}
+			v := run.gitLabVulnerability(result)
+			// results that hash alike are told apart by their occurrence
+			key := v.ID
+			seen[key]++
+			if result.Guid == "" && seen[key] > 1 {
+				v.ID = hashUUID(key, strconv.Itoa(seen[key]))
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, v)
+		}
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	violations, err := ValidateGitLabSAST(b)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return violationsError("GitLab SAST report", violations)
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// ValidateGitLabSAST checks the GitLab SAST report in data against the
+// embedded copy of the report schema. It returns the violations it finds, or
+// an error if data is not JSON.
+func ValidateGitLabSAST(data []byte) ([]*SchemaViolation, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("reading GitLab SAST report: %w", err)
+	}
+	return gitLabSASTSchema.validate(v, ""), nil
+}
+
+// gitLabScanOf describes the scan that produced log.
+func gitLabScanOf(log *SARIF, opts *GitLabSASTOptions) gitLabScan {
+	scan := gitLabScan{Type: "sast", Status: "success"}
+	var start, end time.Time
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		if scan.Scanner == nil && run.Tool != nil && run.Tool.Driver != nil {
+			scan.Scanner = gitLabToolOf(run.Tool.Driver)
+		}
+		for _, inv := range run.Invocations {
+			if inv == nil {
+				continue
+			}
+			if !inv.ExecutionSuccessful {
+				scan.Status = "failure"
+			}
+			if t, err := time.Parse(time.RFC3339, inv.StartTimeUtc); err == nil && (start.IsZero() || t.Before(start)) {
+				start = t
+			}
+			if t, err := time.Parse(time.RFC3339, inv.EndTimeUtc); err == nil && t.After(end) {
+				end = t
+			}
+			for _, n := range inv.ToolExecutionNotifications {
+				if n != nil {
+					level := withDefault(gitLabMessageLevels[n.Level], "warn")
+					scan.Messages = append(scan.Messages, &gitLabMessage{Level: level, Value: describeNotification(run, n)})
+				}
+			}
+		}
+	}
+	if scan.Scanner == nil {
+		scan.Scanner = gitLabToolOf(&ToolComponent{})
+	}
+	scan.Analyzer = scan.Scanner
+	if a := opts.Analyzer; a != nil {
+		name := withDefault(a.Name, a.ID)
+		scan.Analyzer = &gitLabTool{
+			ID:      withDefault(a.ID, gitLabID(name)),
+			Name:    name,
+			URL:     a.URL,
+			Vendor:  gitLabVendor{Name: withDefault(a.Vendor, name)},
+			Version: withDefault(a.Version, "unknown"),
+		}
+	}
+
+	now := opts.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	switch {
+	case start.IsZero() && end.IsZero():
+		start, end = now, now
+	case start.IsZero():
+		start = end
+	case end.IsZero():
+		end = start
+	}
+	scan.StartTime = start.UTC().Format(gitLabTimeLayout)
+	scan.EndTime = end.UTC().Format(gitLabTimeLayout)
+	return scan
+}
+
+func gitLabToolOf(driver *ToolComponent) *gitLabTool {
+	name := withDefault(driver.Name, "unknown")
+	t := &gitLabTool{
+		ID:      gitLabID(name),
+		Name:    name,
+		Vendor:  gitLabVendor{Name: withDefault(driver.Organization, name)},
+		Version: withDefault(driver.SemanticVersion, withDefault(driver.Version, "unknown")),
+	}
+	if isWebURL(driver.InformationUri) {
+		t.URL = driver.InformationUri
+	}
+	return t
+}
+
+// gitLabID turns s into an identifier of lowercase letters, digits, hyphens
+// and underscores.
+func gitLabID(s string) string {
+	id := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, s), "-")
+	return withDefault(id, "unknown")
+}
+
+// gitLabVulnerability converts result.
+func (run *Run) gitLabVulnerability(result *Result) *gitLabVulnerability {
+	msg, err := run.FormatMessage(result, false)
+	if err != nil {
+		msg = "(" + err.Error() + ")"
+	}
+	toolName := ""
+	if run.Tool != nil && run.Tool.Driver != nil {
+		toolName = run.Tool.Driver.Name
+	}
+	ruleID := withDefault(run.ruleID(result), gitLabID(toolName))
+	rule := run.rule(result)
+
+	v := &gitLabVulnerability{
+		Name:        ruleID,
+		Description: truncateRunes(msg, 1048576),
+		Severity:    run.gitLabSeverity(result),
+	}
+	primary := &gitLabIdentifier{Type: gitLabID(toolName) + "_id", Name: ruleID, Value: ruleID}
+	if rule != nil {
+		if rule.ShortDescription != nil && rule.ShortDescription.Text != "" {
+			v.Name = rule.ShortDescription.Text
+		} else if rule.Name != "" {
+			v.Name = rule.Name
+		}
+		if rule.Help != nil {
+			v.Solution = truncateRunes(withDefault(rule.Help.Text, rule.Help.Markdown), 7000)
+		}
+		if isWebURL(rule.HelpUri) {
+			primary.URL = rule.HelpUri
+		}
+	}
+	v.Name = truncateRunes(v.Name, 255)
+	v.Identifiers = append([]*gitLabIdentifier{primary}, run.gitLabTaxonIdentifiers(result)...)
+	if len(v.Identifiers) > maxGitLabIdentifiers {
+		v.Identifiers = v.Identifiers[:maxGitLabIdentifiers]
+	}
+
+	for _, loc := range result.Locations {
+		if loc == nil || loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation == nil {
+			continue
+		}
+		pl := loc.PhysicalLocation
+		v.Location.File = run.reportPath(pl.ArtifactLocation)
+		if r := pl.Region; r != nil && r.StartLine > 0 {
+			v.Location.StartLine = r.StartLine
+			v.Location.EndLine = withDefaultInt(r.EndLine, r.StartLine)
+		}
+		for _, ll := range loc.LogicalLocations {
+			if ll == nil {
+				continue
+			}
+			name := withDefault(ll.FullyQualifiedName, ll.Name)
+			switch ll.Kind {
+			case "function", "member":
+				v.Location.Method = name
+			case "type":
+				v.Location.Class = name
+			}
+		}
+		break
+	}
+
+	if result.Guid != "" {
+		v.ID = result.Guid
+	} else {
+		parts := []string{toolName, ruleID, v.Location.File, strconv.Itoa(v.Location.StartLine), msg}
+		for _, k := range sortedKeys(result.PartialFingerprints) {
+			parts = append(parts, k+"="+result.PartialFingerprints[k])
+		}
+		v.ID = hashUUID(parts...)
+	}
+	return v
+}
+
+// gitLabSeverity returns the severity of result, from the CVSS score in the
+// "security-severity" property of its rule if it has one, and from its
+// effective level otherwise.
+func (run *Run) gitLabSeverity(result *Result) string {
+	if score, ok := securitySeverity(run.rule(result)); ok {
+		switch {
+		case score >= 9:
+			return "Critical"
+		case score >= 7:
+			return "High"
+		case score >= 4:
+			return "Medium"
+		case score > 0:
+			return "Low"
+		}
+		return "Info"
+	}
+	switch run.EffectiveLevel(result) {
+	case "error":
+		return "High"
+	case "warning":
+		return "Medium"
+	case "note":
+		return "Low"
+	case "none":
+		return "Info"
+	}
+	return "Unknown"
+}
+
+// securitySeverity returns the CVSS score in the "security-severity" property
+// of rule, which is a string by convention.
+func securitySeverity(rule *ReportingDescriptor) (float64, bool) {
+	if rule == nil || rule.Properties == nil {
+		return 0, false
+	}
+	switch s := rule.Properties.AdditionalProperties["security-severity"].(type) {
+	case string:
+		score, err := strconv.ParseFloat(s, 64)
+		return score, err == nil
+	case float64:
+		return s, true
+	}
+	return 0, false
+}
+
+// gitLabTaxonIdentifiers returns identifiers for the taxa of result and of
+// its rule, without duplicates.
+func (run *Run) gitLabTaxonIdentifiers(result *Result) []*gitLabIdentifier {
+	var ids []*gitLabIdentifier
+	seen := make(map[string]bool)
+	for _, ref := range run.resultTaxa(result) {
+		id, component, description, url := ref.Id, "", "", ""
+		if taxon, tc, err := run.ResolveDescriptor(ref, TaxonDescriptor); err == nil {
+			id, component, url = taxon.Id, tc.Name, taxon.HelpUri
+			if taxon.ShortDescription != nil {
+				description = taxon.ShortDescription.Text
+			}
+		} else if ref.ToolComponent != nil {
+			component = ref.ToolComponent.Name
+		}
+		if id == "" {
+			continue
+		}
+		ident := gitLabTaxonIdentifier(component, id, description)
+		if isWebURL(url) {
+			ident.URL = url
+		}
+		if key := ident.Type + "\x00" + ident.Value; !seen[key] {
+			seen[key] = true
+			ids = append(ids, ident)
+		}
+	}
+	return ids
+}
+
+// gitLabTaxonIdentifier returns the identifier of the taxon id of the named
+// taxonomy.
+func gitLabTaxonIdentifier(taxonomy, id, description string) *gitLabIdentifier {
+	if strings.EqualFold(taxonomy, "CWE") || strings.HasPrefix(strings.ToUpper(id), "CWE-") {
+		n := id[strings.LastIndex(id, "-")+1:]
+		return &gitLabIdentifier{
+			Type:  "cwe",
+			Name:  "CWE-" + n,
+			Value: n,
+			URL:   "https://cwe.mitre.org/data/definitions/" + n + ".html",
+		}
+	}
+	name := id
+	if description != "" {
+		name += " - " + description
+	}
+	typ := gitLabID(withDefault(taxonomy, "taxon"))
+	if strings.HasPrefix(strings.ToLower(taxonomy), "owasp") {
+		typ = "owasp"
+	}
+	return &gitLabIdentifier{Type: typ, Name: name, Value: id}
+}
+
+func isWebURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// truncateRunes shortens s to at most n runes, ending it with an ellipsis if
+// it had to be cut.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+// hashUUID returns a UUID derived from a hash of parts, in the layout of an
+// RFC 9562 version 8 UUID.
+func hashUUID(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%s\x00", p)
+	}
+	b := h.Sum(nil)[:16]
+	b[6] = b[6]&0x0f | 0x80
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
@@ -0,0 +1,112 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWriteGitLabSAST(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &GitLabSASTOptions{Analyzer: &GitLabTool{ID: "sarif", Name: "sarif", Version: "2.0.0"}}
+	if err := WriteGitLabSAST(&buf, readTestLog(t, "sample.sarif"), opts); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "gitlab-sast.json", buf.Bytes())
+}
+
+func TestWriteGitLabSASTIdentifierLimit(t *testing.T) {
+	taxonomy := &ToolComponent{Name: "CWE"}
+	result := &Result{RuleId: "R1", Message: &Message{Text: "m"}, Locations: []*Location{{
+		PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: "x.go", Index: -1}},
+	}}}
+	for i := 1; i <= 30; i++ {
+		id := "CWE-" + strconv.Itoa(i)
+		taxonomy.Taxa = append(taxonomy.Taxa, &ReportingDescriptor{Id: id})
+		result.Taxa = append(result.Taxa, &ReportingDescriptorReference{Id: id, Index: -1, ToolComponent: &ToolComponentReference{Name: "CWE", Index: -1}})
+	}
+	log := &SARIF{Runs: []*Run{{
+		Tool:       &Tool{Driver: &ToolComponent{Name: "t"}},
+		Taxonomies: []*ToolComponent{taxonomy},
+		Results:    []*Result{result},
+	}}}
+	var buf bytes.Buffer
+	if err := WriteGitLabSAST(&buf, log, &GitLabSASTOptions{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteGitLabSASTLocationless(t *testing.T) {
+	log := &SARIF{Runs: []*Run{{
+		Tool: &Tool{Driver: &ToolComponent{Name: "t"}},
+		Results: []*Result{
+			{RuleId: "R1", Message: &Message{Text: "no location"}},
+			{RuleId: "R2", Message: &Message{Text: "logical location only"}, Locations: []*Location{{
+				LogicalLocations: []*LogicalLocation{{Name: "main", Kind: "function", Index: -1, ParentIndex: -1}},
+			}}},
+			{RuleId: "R3", Message: &Message{Text: "in a file"}, Locations: []*Location{{
+				PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: "x.go", Index: -1}},
+			}}},
+		},
+	}}}
+	var buf bytes.Buffer
+	if err := WriteGitLabSAST(&buf, log, nil); err != nil {
+		t.Fatal(err)
+	}
+	var report gitLabReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].Location.File != "x.go" {
+		t.Errorf("vulnerabilities = %+v, want only that of the result in x.go", report.Vulnerabilities)
+	}
+}
+
+func TestValidateGitLabSAST(t *testing.T) {
+	const scan = `"scan": {"analyzer": {"id": "a", "name": "a", "version": "1", "vendor": {"name": "v"}},
+		"scanner": {"id": "s", "name": "s", "version": "1", "vendor": {"name": "v"}},
+		"type": "sast", "start_time": "2024-05-01T10:00:00", "end_time": "2024-05-01T10:00:02", "status": "success"}`
+	vulnerability := func(extra string) string {
+		return `{"version": "15.0.7", ` + scan + `, "vulnerabilities": [{"id": "1", "identifiers": [{"type": "t", "name": "n", "value": "v"}], "location": {}` + extra + `}]}`
+	}
+	tests := []struct {
+		name   string
+		report string
+		valid  bool
+	}{
+		{"minimal", vulnerability(""), true},
+		{"details", vulnerability(`, "details": {"f": {"name": "Field", "type": "text", "value": "x"}, "l": {"name": "List", "type": "list", "items": [{"type": "url", "href": "https://example.com"}]}}`), true},
+		{"details of an unknown type", vulnerability(`, "details": {"f": {"name": "Field", "type": "blob", "value": "x"}}`), false},
+		{"details without a name", vulnerability(`, "details": {"f": {"type": "text", "value": "x"}}`), false},
+		{"tracking", vulnerability(`, "tracking": {"type": "source", "items": [{"file": "x.go", "start_line": 1, "end_line": 1, "signatures": [{"algorithm": "hash", "value": "abc"}]}]}`), true},
+		{"tracking without signatures", vulnerability(`, "tracking": {"type": "source", "items": [{"signatures": []}]}`), false},
+		{"flags", vulnerability(`, "flags": [{"type": "flagged-as-likely-false-positive", "origin": "o", "description": "d"}]`), true},
+		{"unknown flag", vulnerability(`, "flags": [{"type": "other", "origin": "o", "description": "d"}]`), false},
+		{"bad severity", vulnerability(`, "severity": "Severe"`), false},
+		{"bad analyzer url", `{"version": "15.0.7", "scan": {"analyzer": {"id": "a", "name": "a", "version": "1", "vendor": {"name": "v"}, "url": "ftp://x"}}, "vulnerabilities": []}`, false},
+		{"missing scan", `{"version": "15.0.7", "vulnerabilities": []}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := ValidateGitLabSAST([]byte(tt.report))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (len(violations) == 0) != tt.valid {
+				t.Errorf("ValidateGitLabSAST() = %v, want valid = %v", violations, tt.valid)
+			}
+		})
+	}
+}
+
+func TestWriteGitLabSASTInvalid(t *testing.T) {
+	log := readTestLog(t, "sample.sarif")
+	var buf bytes.Buffer
+	err := WriteGitLabSAST(&buf, log, &GitLabSASTOptions{Analyzer: &GitLabTool{ID: "a", URL: "not a url"}})
+	if !errors.Is(err, ErrSchemaViolation) || buf.Len() > 0 {
+		t.Errorf("WriteGitLabSAST() = %v and wrote %d bytes, want a schema violation and no output", err, buf.Len())
+	}
+}
@@ -0,0 +1,243 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// golangciLevels maps golangci-lint severities onto levels. Issues have no
+// severity unless one is configured, and then get no level, which stands for
+// "warning".
+var golangciLevels = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"info":    "note",
+	"note":    "note",
+	"ignore":  "none",
+}
+
+type golangciReport struct {
+	Issues []*golangciIssue `json:"Issues"`
+	Report *struct {
+		Linters []struct {
+			Name    string `json:"Name"`
+			Enabled bool   `json:"Enabled"`
+		} `json:"Linters"`
+		Warnings []struct {
+			Tag  string `json:"Tag"`
+			Text string `json:"Text"`
+		} `json:"Warnings"`
+		Error string `json:"Error"`
+	} `json:"Report"`
+}
+
+type golangciIssue struct {
+	FromLinter  string               `json:"FromLinter"`
+	Text        string               `json:"Text"`
+	Severity    string               `json:"Severity"`
+	SourceLines []string             `json:"SourceLines"`
+	Replacement *golangciReplacement `json:"Replacement"`
+	Pos         struct {
+		Filename string `json:"Filename"`
+		Line     int    `json:"Line"`
+		Column   int    `json:"Column"`
+	} `json:"Pos"`
+	LineRange *struct {
+		From int `json:"From"`
+		To   int `json:"To"`
+	} `json:"LineRange"`
+}
+
+type golangciReplacement struct {
+	NeedOnlyDelete bool     `json:"NeedOnlyDelete"`
+	NewLines       []string `json:"NewLines"`
+	Inline         *struct {
+		StartCol  int    `json:"StartCol"`
+		Length    int    `json:"Length"`
+		NewString string `json:"NewString"`
+	} `json:"Inline"`
+}
+
+// ReadGolangCILint converts the output of golangci-lint run with
+// --out-format json, read from r, into a SARIF log with a single run. Each
+// linter that reported an issue or that the report lists as enabled becomes
+// a rule, and each issue a result whose region spans its line range, with
+// the suggested replacement, if any, as a fix. The source lines reported
+// with an issue become the snippet of a context region that spans them, and
+// of the region itself if it spans exactly those lines. The warnings and
+// error of the report become notifications of the run's invocation; the tag
+// of a warning becomes a notification descriptor of the driver.
+//
+// golangci-lint counts columns in bytes, while SARIF counts them in UTF-16
+// code units, so columns are converted through the reported source line:
+// regions have a start column, and inline replacements become fixes, only
+// for issues whose source line was reported.
+//
+// Run.Conversion records that the run was converted by this package; as r
+// need not be a file, callers that read the report from one should add it to
+// the conversion's AnalysisToolLogFiles.
+func ReadGolangCILint(r io.Reader) (*SARIF, error) {
+	var report golangciReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("reading golangci-lint JSON: %w", err)
+	}
+	log := newImportedLog()
+	run := newImportedRun("golangci-lint")
+	run.Tool.Driver.InformationUri = "https://golangci-lint.run"
+	run.Conversion = &Conversion{
+		Tool: &Tool{Driver: &ToolComponent{Name: "sarif", InformationUri: "https://github.com/tjgurwara99/sarif"}},
+	}
+	log.Runs = append(log.Runs, run)
+	rules := make(map[string]int)
+
+	if rep := report.Report; rep != nil {
+		for _, l := range rep.Linters {
+			if l.Enabled {
+				run.importedRule(rules, l.Name)
+			}
+		}
+		inv := &Invocation{ExecutionSuccessful: rep.Error == ""}
+		tags := make(map[string]int)
+		for _, w := range rep.Warnings {
+			n := &Notification{Level: "warning", Message: &Message{Text: w.Text}}
+			if w.Tag != "" {
+				i, ok := tags[w.Tag]
+				if !ok {
+					driver := run.Tool.Driver
+					driver.Notifications = append(driver.Notifications, &ReportingDescriptor{Id: w.Tag})
+					i = len(driver.Notifications) - 1
+					tags[w.Tag] = i
+				}
+				n.Descriptor = &ReportingDescriptorReference{Id: w.Tag, Index: i}
+			}
+			inv.ToolExecutionNotifications = append(inv.ToolExecutionNotifications, n)
+		}
+		if rep.Error != "" {
+			inv.ToolExecutionNotifications = append(inv.ToolExecutionNotifications, &Notification{Level: "error", Message: &Message{Text: rep.Error}})
+		}
+		run.Invocations = []*Invocation{inv}
+	}
+
+	for i, issue := range report.Issues {
+		if issue == nil {
+			continue
+		}
+		if issue.Pos.Filename == "" {
+			return nil, fmt.Errorf("golangci-lint issue %d has no file name", i)
+		}
+		result := &Result{
+			RuleId:    issue.FromLinter,
+			RuleIndex: -1,
+			Level:     golangciLevels[strings.ToLower(issue.Severity)],
+			Message:   &Message{Text: issue.Text},
+			Rank:      -1,
+		}
+		if issue.FromLinter != "" {
+			result.RuleIndex = run.importedRule(rules, issue.FromLinter)
+		}
+		artifact := &ArtifactLocation{Uri: pathURI(issue.Pos.Filename), Index: -1}
+		pl := &PhysicalLocation{ArtifactLocation: artifact}
+		if issue.Pos.Line > 0 {
+			pl.Region = &Region{StartLine: issue.Pos.Line}
+			if line, ok := issue.sourceLine(issue.Pos.Line); ok && issue.Pos.Column > 0 {
+				pl.Region.StartColumn, _ = utf16Column(line, issue.Pos.Column)
+			}
+			if lr := issue.LineRange; lr != nil && lr.To > issue.Pos.Line {
+				pl.Region.EndLine = lr.To
+			}
+			if first, last := issue.sourceLines(); len(issue.SourceLines) > 0 {
+				snippet := &ArtifactContent{Text: strings.Join(issue.SourceLines, "\n")}
+				pl.ContextRegion = &Region{StartLine: first, EndLine: last, Snippet: snippet}
+				if pl.Region.StartColumn <= 1 && first == pl.Region.StartLine && last == withDefaultInt(pl.Region.EndLine, pl.Region.StartLine) {
+					pl.Region.Snippet = snippet.Clone()
+				}
+			}
+		}
+		result.Locations = []*Location{{PhysicalLocation: pl}}
+		if fix := issue.fix(artifact); fix != nil {
+			result.Fixes = []*Fix{fix}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return log, nil
+}
+
+// fix converts the replacement of issue, which applies to artifact.
+func (issue *golangciIssue) fix(artifact *ArtifactLocation) *Fix {
+	rep := issue.Replacement
+	if rep == nil || issue.Pos.Line <= 0 {
+		return nil
+	}
+	from, to := issue.Pos.Line, issue.Pos.Line
+	if lr := issue.LineRange; lr != nil && lr.From > 0 && lr.To >= lr.From {
+		from, to = lr.From, lr.To
+	}
+	var replacement *Replacement
+	switch {
+	case rep.Inline != nil:
+		// the start column and length count bytes within the line
+		line, ok := issue.sourceLine(issue.Pos.Line)
+		if !ok {
+			return nil
+		}
+		start, ok1 := utf16Column(line, rep.Inline.StartCol+1)
+		end, ok2 := utf16Column(line, rep.Inline.StartCol+rep.Inline.Length+1)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		replacement = &Replacement{
+			DeletedRegion:   &Region{StartLine: issue.Pos.Line, StartColumn: start, EndColumn: end},
+			InsertedContent: &ArtifactContent{Text: rep.Inline.NewString},
+		}
+	case rep.NeedOnlyDelete:
+		// the region ends at the end of line to rather than at the start of
+		// the next line, which the file need not have; its newline is kept
+		replacement = &Replacement{DeletedRegion: &Region{StartLine: from, EndLine: to}}
+	default:
+		replacement = &Replacement{
+			DeletedRegion:   &Region{StartLine: from, EndLine: to},
+			InsertedContent: &ArtifactContent{Text: strings.Join(rep.NewLines, "\n")},
+		}
+	}
+	return &Fix{
+		Description: &Message{Text: "Apply the replacement suggested by " + withDefault(issue.FromLinter, "golangci-lint")},
+		ArtifactChanges: []*ArtifactChange{{
+			ArtifactLocation: &ArtifactLocation{Uri: artifact.Uri, Index: -1},
+			Replacements:     []*Replacement{replacement},
+		}},
+	}
+}
+
+// sourceLines returns the numbers of the first and last of the source lines
+// reported with issue, which span its line range.
+func (issue *golangciIssue) sourceLines() (int, int) {
+	first := issue.Pos.Line
+	if lr := issue.LineRange; lr != nil && lr.From > 0 {
+		first = lr.From
+	}
+	return first, first + len(issue.SourceLines) - 1
+}
+
+// sourceLine returns the source line n if it was reported with issue.
+func (issue *golangciIssue) sourceLine(n int) (string, bool) {
+	first, last := issue.sourceLines()
+	if n < first || n > last {
+		return "", false
+	}
+	return issue.SourceLines[n-first], true
+}
+
+// utf16Column converts the 1-based byte column col of line into a UTF-16
+// column, failing if col is not at the start of a character of line or just
+// past its end.
+func utf16Column(line string, col int) (int, bool) {
+	off := col - 1
+	if off < 0 || off > len(line) || off < len(line) && !utf8.RuneStart(line[off]) {
+		return 0, false
+	}
+	_, column := newLineIndex([]byte(line)).position(off)
+	return column, true
+}
@@ -0,0 +1,135 @@
+package sarif
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadGolangCILint(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "golangci.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := ReadGolangCILint(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "golangci-import.sarif", marshalTestLog(t, log))
+}
+
+func TestGolangCILintRegions(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "golangci.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := ReadGolangCILint(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := log.Runs[0].Results
+	region := func(i int) *Region { return results[i].Locations[0].PhysicalLocation.Region }
+	context := func(i int) *Region { return results[i].Locations[0].PhysicalLocation.ContextRegion }
+	deleted := func(i int) *Region {
+		if len(results[i].Fixes) == 0 {
+			return nil
+		}
+		return results[i].Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion
+	}
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		// "\tfmt.Println(\"𝄞 héllo\")": the byte column 20 is the "h",
+		// after a tab, 13 ASCII bytes, a surrogate pair and a space
+		{"start column in utf-16", region(0).StartColumn, 18},
+		{"inline replacement start", deleted(0).StartColumn, 18},
+		{"inline replacement end", deleted(0).EndColumn, 23},
+		{"no snippet on a region starting mid-line", region(0).Snippet == nil, true},
+		{"context snippet", context(0).Snippet.Text, "\tfmt.Println(\"𝄞 héllo\")"},
+		{"snippet of a whole-line range", region(1).Snippet != nil && region(1).Snippet.Text == "func a() {\n\tb()\n\tc()\n}", true},
+		{"context of a range", [2]int{context(1).StartLine, context(1).EndLine}, [2]int{6, 9}},
+		{"whole line without column", region(2).StartColumn, 0},
+		{"column without a source line", region(4).StartColumn, 0},
+		{"inline fix without a source line", len(results[4].Fixes), 0},
+		{"rule of an unnamed linter", results[4].RuleIndex, -1},
+		{"deletion ends within its last line", [2]int{deleted(2).EndLine, deleted(2).EndColumn}, [2]int{31, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+	for _, rule := range log.Runs[0].Tool.Driver.Rules {
+		if rule.Id == "" {
+			t.Error("driver has a rule without id")
+		}
+	}
+	// the deletion applies to a file whose last line has no newline
+	content := []byte(strings.Repeat("x\n", 30) + "x")
+	if _, err := applyReplacements(content, results[2].Fixes[0].ArtifactChanges[0].Replacements); err != nil {
+		t.Errorf("applying the deletion: %v", err)
+	}
+}
+
+func TestGolangCILintNotifications(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "golangci.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := ReadGolangCILint(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := log.Runs[0]
+	n := run.Invocations[0].ToolExecutionNotifications[0]
+	d, _, err := run.ResolveDescriptor(n.Descriptor, NotificationDescriptor)
+	if err != nil || d == nil || d.Id != "runner" {
+		t.Errorf("descriptor of the warning = %v, %v, want the notification descriptor runner", d, err)
+	}
+}
+
+func TestUTF16Column(t *testing.T) {
+	tests := []struct {
+		line string
+		col  int
+		want int
+		ok   bool
+	}{
+		{"abc", 1, 1, true},
+		{"abc", 4, 4, true},
+		{"héllo", 4, 3, true},
+		{"héllo", 3, 0, false},
+		{"𝄞x", 5, 3, true},
+		{"abc", 5, 0, false},
+		{"abc", 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := utf16Column(tt.line, tt.col)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("utf16Column(%q, %d) = %d, %v, want %d, %v", tt.line, tt.col, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadGolangCILintErrors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"malformed", `{"Issues": [`, "reading golangci-lint JSON"},
+		{"no file name", `{"Issues": [{"FromLinter": "x", "Pos": {"Line": 1}}]}`, "has no file name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadGolangCILint(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadGolangCILint() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
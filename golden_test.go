@@ -0,0 +1,55 @@
+package sarif
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// readTestLog decodes the SARIF log testdata/name.
+func readTestLog(t *testing.T, name string) *SARIF {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := &SARIF{}
+	if err := json.Unmarshal(data, log); err != nil {
+		t.Fatal(err)
+	}
+	return log
+}
+
+// marshalTestLog encodes log as the sarif command writes it.
+func marshalTestLog(t *testing.T, log *SARIF) []byte {
+	t.Helper()
+	b, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(b, '\n')
+}
+
+// checkGolden compares got with the golden file testdata/name, or rewrites
+// the file if the -update flag is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run go test -update to create it", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("output differs from %s; run go test -update to accept it\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,594 @@
+package sarif
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions configures WriteHTML.
+type HTMLOptions struct {
+	// Title is the title of the report; "SARIF report" if empty.
+	Title string
+	// Source returns the content of the artifact with the given URI, as
+	// resolved by Run.ResolveURI where possible. It is used for source
+	// snippets and fix diffs when the log does not embed the artifact's
+	// contents. If nil, only embedded contents and snippets are shown.
+	Source func(uri string) ([]byte, error)
+	// ContextLines is the number of lines shown around the region of a
+	// result.
+	ContextLines int
+}
+
+// WriteHTML writes a self-contained HTML report of log to w, without any
+// external assets: a summary, followed for each run by a section per rule
+// with its descriptions and help and the rule's results. Each result shows
+// its formatted message, source snippet, baseline and suppression state, and
+// its code flows, stacks and fixes, the latter as diffs. opts may be nil.
+func WriteHTML(w io.Writer, log *SARIF, opts *HTMLOptions) error {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	r := &htmlRenderer{opts: opts, sources: make(map[string][]byte)}
+	report := &htmlReport{Title: withDefault(opts.Title, "SARIF report"), Summary: Summarize(log)}
+	for i, run := range log.Runs {
+		if run != nil {
+			report.Runs = append(report.Runs, r.run(i, run))
+		}
+	}
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, report); err != nil {
+		return err
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+type htmlReport struct {
+	Title   string
+	Summary *Summary
+	Runs    []*htmlRun
+}
+
+type htmlRun struct {
+	Index int
+	Tool  string
+	Rules []*htmlRule
+}
+
+type htmlRule struct {
+	Anchor, ID, Name, Level string
+	Short, Full, Help       string
+	HelpURI                 string
+	Results                 []*htmlResult
+}
+
+type htmlResult struct {
+	Anchor, Level, Location, Message string
+	Kind, Baseline, Suppression      string
+	Justification                    string
+	Snippet                          []htmlLine
+	Flows                            []*htmlFlow
+	Stacks                           []*htmlStack
+	Fixes                            []*htmlFix
+}
+
+type htmlLine struct {
+	Number    int
+	Text      string
+	Highlight bool
+	Op        string
+}
+
+type htmlFlow struct {
+	Message string
+	Threads []*htmlThread
+}
+
+type htmlThread struct {
+	ID, Message string
+	Steps       []*htmlStep
+}
+
+type htmlStep struct {
+	Anchor, Location, Message, Importance, Source string
+	Number, Nesting                               int
+}
+
+type htmlStack struct {
+	Message string
+	Frames  []string
+}
+
+type htmlFix struct {
+	Description string
+	Changes     []*htmlChange
+}
+
+type htmlChange struct {
+	URI   string
+	Lines []htmlLine
+	Note  string
+}
+
+type htmlRenderer struct {
+	opts    *HTMLOptions
+	sources map[string][]byte
+}
+
+func (r *htmlRenderer) run(i int, run *Run) *htmlRun {
+	hr := &htmlRun{Index: i}
+	if run.Tool != nil && run.Tool.Driver != nil {
+		d := run.Tool.Driver
+		hr.Tool = strings.TrimSpace(d.Name + " " + withDefault(d.SemanticVersion, d.Version))
+	}
+	rules := make(map[*ReportingDescriptor]*htmlRule)
+	var other *htmlRule
+	for j, result := range run.Results {
+		if result == nil {
+			continue
+		}
+		rule, _, _ := run.RuleFor(result)
+		group := rules[rule]
+		if rule == nil {
+			if other == nil {
+				other = &htmlRule{Anchor: fmt.Sprintf("run%d-other", i), ID: "Other results"}
+			}
+			group = other
+		} else if group == nil {
+			group = &htmlRule{
+				Anchor:  fmt.Sprintf("run%d-rule-%s", i, anchorName(rule.Id)),
+				ID:      rule.Id,
+				Name:    rule.Name,
+				Short:   messageText(rule.ShortDescription),
+				Full:    messageText(rule.FullDescription),
+				Help:    messageText(rule.Help),
+				HelpURI: rule.HelpUri,
+			}
+			if c := rule.DefaultConfiguration; c != nil {
+				group.Level = c.Level
+			}
+			rules[rule] = group
+			hr.Rules = append(hr.Rules, group)
+		}
+		group.Results = append(group.Results, r.result(i, j, run, result))
+	}
+	if other != nil {
+		hr.Rules = append(hr.Rules, other)
+	}
+	return hr
+}
+
+func (r *htmlRenderer) result(i, j int, run *Run, result *Result) *htmlResult {
+	hr := &htmlResult{
+		Anchor:      fmt.Sprintf("run%d-result%d", i, j),
+		Level:       run.EffectiveLevel(result),
+		Kind:        withDefault(result.Kind, "fail"),
+		Baseline:    result.BaselineState,
+		Suppression: suppressionStatus(result),
+	}
+	for _, s := range result.Suppressions {
+		if s != nil && s.Justification != "" {
+			hr.Justification = s.Justification
+			break
+		}
+	}
+	if msg, err := run.FormatMessage(result, false); err == nil {
+		hr.Message = msg
+	} else {
+		hr.Message = "(" + err.Error() + ")"
+	}
+	if len(result.Locations) > 0 {
+		hr.Location = r.describeLocation(run, result.Locations[0])
+	}
+	if pl := primaryLocation(result); pl != nil {
+		hr.Snippet = r.snippet(run, pl)
+	}
+	for k, cf := range result.CodeFlows {
+		if cf == nil {
+			continue
+		}
+		flow := &htmlFlow{Message: plainMessage(cf.Message)}
+		for l, tf := range cf.ThreadFlows {
+			if tf == nil {
+				continue
+			}
+			thread := &htmlThread{ID: tf.Id, Message: plainMessage(tf.Message)}
+			for m, tfl := range tf.Locations {
+				if tfl == nil {
+					continue
+				}
+				step := &htmlStep{
+					Anchor:     fmt.Sprintf("%s-flow%d-%d-%d", hr.Anchor, k, l, m),
+					Number:     m + 1,
+					Nesting:    tfl.NestingLevel,
+					Importance: withDefault(tfl.Importance, "important"),
+				}
+				if loc := tfl.Location; loc != nil {
+					step.Location = r.describeLocation(run, loc)
+					step.Message = plainMessage(loc.Message)
+					if loc.PhysicalLocation != nil {
+						if lines := r.regionLines(run, loc.PhysicalLocation, 0); len(lines) > 0 {
+							step.Source = strings.TrimSpace(lines[0].Text)
+						}
+					}
+				}
+				thread.Steps = append(thread.Steps, step)
+			}
+			flow.Threads = append(flow.Threads, thread)
+		}
+		hr.Flows = append(hr.Flows, flow)
+	}
+	for _, st := range result.Stacks {
+		if st == nil {
+			continue
+		}
+		stack := &htmlStack{Message: plainMessage(st.Message)}
+		for _, f := range st.Frames {
+			if f == nil {
+				continue
+			}
+			frame := ""
+			if f.Location != nil {
+				frame = r.describeLocation(run, f.Location)
+			}
+			if f.Module != "" {
+				frame = f.Module + "!" + frame
+			}
+			stack.Frames = append(stack.Frames, frame)
+		}
+		hr.Stacks = append(hr.Stacks, stack)
+	}
+	for _, fix := range result.Fixes {
+		if fix != nil {
+			hr.Fixes = append(hr.Fixes, r.fix(run, fix))
+		}
+	}
+	return hr
+}
+
+// describeLocation renders loc as "uri:line:column", falling back to the name
+// of its logical location.
+func (r *htmlRenderer) describeLocation(run *Run, loc *Location) string {
+	if loc == nil {
+		return ""
+	}
+	if pl := loc.PhysicalLocation; pl != nil && pl.ArtifactLocation != nil {
+		s := run.displayURI(pl.ArtifactLocation)
+		if reg := pl.Region; reg != nil && reg.StartLine > 0 {
+			s += ":" + strconv.Itoa(reg.StartLine)
+			if reg.StartColumn > 0 {
+				s += ":" + strconv.Itoa(reg.StartColumn)
+			}
+		}
+		return s
+	}
+	for _, ll := range loc.LogicalLocations {
+		if ll != nil {
+			return withDefault(ll.FullyQualifiedName, ll.Name)
+		}
+	}
+	return ""
+}
+
+// source returns the content of the artifact of loc, from the log or from
+// HTMLOptions.Source, or nil if it is not available.
+func (r *htmlRenderer) source(run *Run, loc *ArtifactLocation) []byte {
+	if a := run.artifactFor(loc); a != nil && a.Contents != nil && a.Contents.Text != "" {
+		return []byte(a.Contents.Text)
+	}
+	if r.opts.Source == nil {
+		return nil
+	}
+	uri := loc.Uri
+	if u, err := run.ResolveURI(loc); err == nil {
+		uri = u.String()
+	}
+	if b, ok := r.sources[uri]; ok {
+		return b
+	}
+	b, err := r.opts.Source(uri)
+	if err != nil {
+		b = nil
+	}
+	r.sources[uri] = b
+	return b
+}
+
+// snippet returns the lines of the region of pl with HTMLOptions.ContextLines
+// of context around them.
+func (r *htmlRenderer) snippet(run *Run, pl *PhysicalLocation) []htmlLine {
+	if lines := r.regionLines(run, pl, r.opts.ContextLines); lines != nil {
+		return lines
+	}
+	for _, reg := range []*Region{pl.Region, pl.ContextRegion} {
+		if reg != nil && reg.Snippet != nil && reg.Snippet.Text != "" {
+			var lines []htmlLine
+			for k, text := range strings.Split(strings.TrimRight(reg.Snippet.Text, "\n"), "\n") {
+				n := 0
+				if reg.StartLine > 0 {
+					n = reg.StartLine + k
+				}
+				lines = append(lines, htmlLine{Number: n, Text: text, Highlight: reg == pl.Region})
+			}
+			return lines
+		}
+	}
+	return nil
+}
+
+// regionLines returns the lines of the artifact that the region of pl covers,
+// with context lines around them, or nil if the artifact is not available.
+func (r *htmlRenderer) regionLines(run *Run, pl *PhysicalLocation, context int) []htmlLine {
+	if pl.ArtifactLocation == nil || pl.Region == nil {
+		return nil
+	}
+	content := r.source(run, pl.ArtifactLocation)
+	if content == nil {
+		return nil
+	}
+	idx := newLineIndex(content)
+	start, end, ok := idx.offsets(pl.Region)
+	if !ok {
+		return nil
+	}
+	first, _ := idx.position(start)
+	last, _ := idx.position(end)
+	var lines []htmlLine
+	for n := first - context; n <= last+context; n++ {
+		if n < 1 || n > idx.lines() {
+			continue
+		}
+		from := idx.starts[n-1]
+		to := len(content)
+		if n < len(idx.starts) {
+			to = idx.starts[n]
+		}
+		lines = append(lines, htmlLine{Number: n, Text: strings.TrimRight(string(content[from:to]), "\r\n"), Highlight: n >= first && n <= last})
+	}
+	return lines
+}
+
+// lines returns the number of lines of the artifact; a trailing newline does
+// not start another line.
+func (idx *lineIndex) lines() int {
+	if n := len(idx.content); n > 0 && idx.content[n-1] == '\n' {
+		return len(idx.starts) - 1
+	}
+	return len(idx.starts)
+}
+
+func (r *htmlRenderer) fix(run *Run, fix *Fix) *htmlFix {
+	hf := &htmlFix{Description: plainMessage(fix.Description)}
+	for _, ch := range fix.ArtifactChanges {
+		if ch == nil || ch.ArtifactLocation == nil {
+			continue
+		}
+		hc := &htmlChange{URI: run.displayURI(ch.ArtifactLocation)}
+		hf.Changes = append(hf.Changes, hc)
+		if before := r.source(run, ch.ArtifactLocation); before != nil {
+			after, err := applyReplacements(before, ch.Replacements)
+			if err == nil {
+				hc.Lines = diffLinesHTML(before, after)
+				continue
+			}
+			hc.Note = err.Error()
+		}
+		// without the artifact, show the replacements themselves
+		for _, rep := range ch.Replacements {
+			if rep == nil || rep.DeletedRegion == nil {
+				continue
+			}
+			reg := rep.DeletedRegion
+			del := fmt.Sprintf("bytes %d-%d", reg.ByteOffset, reg.ByteOffset+reg.ByteLength)
+			if reg.StartLine > 0 {
+				del = fmt.Sprintf("line %d column %d to line %d column %d", reg.StartLine, reg.StartColumn, withDefaultInt(reg.EndLine, reg.StartLine), reg.EndColumn)
+			}
+			if reg.Snippet != nil && reg.Snippet.Text != "" {
+				del = reg.Snippet.Text
+			}
+			hc.Lines = append(hc.Lines, htmlLine{Number: reg.StartLine, Text: del, Op: "-"})
+			if c := rep.InsertedContent; c != nil && c.Text != "" {
+				for _, text := range strings.Split(strings.TrimSuffix(c.Text, "\n"), "\n") {
+					hc.Lines = append(hc.Lines, htmlLine{Text: text, Op: "+"})
+				}
+			}
+		}
+	}
+	return hf
+}
+
+// diffLinesHTML renders the line diff between before and after, each hunk
+// with a line of context around it.
+func diffLinesHTML(before, after []byte) []htmlLine {
+	const context = 1
+	a, b := splitLines(before), splitLines(after)
+	idxA := newLineIndex(before)
+	var lines []htmlLine
+	lineText := func(l textLine) string { return strings.TrimRight(string(l.text), "\r\n") }
+	lineAt := func(offset int) int {
+		n, _ := idxA.position(offset)
+		if offset == len(before) && len(before) > 0 && before[len(before)-1] == '\n' {
+			n++
+		}
+		return n
+	}
+	shown := 0 // lines of before already shown
+	for _, h := range diffLines(a, b) {
+		first := lineAt(h.delStart)
+		for n := first - context; n < first; n++ {
+			if n > shown && n >= 1 && n <= len(a) {
+				lines = append(lines, htmlLine{Number: n, Text: lineText(a[n-1])})
+			}
+		}
+		n := first
+		for _, l := range splitLines(before[h.delStart:h.delEnd]) {
+			lines = append(lines, htmlLine{Number: n, Text: lineText(l), Op: "-"})
+			n++
+		}
+		for _, l := range splitLines(after[h.insStart:h.insEnd]) {
+			lines = append(lines, htmlLine{Text: lineText(l), Op: "+"})
+		}
+		for k := 0; k < context && n <= len(a); k++ {
+			lines = append(lines, htmlLine{Number: n, Text: lineText(a[n-1])})
+			n++
+		}
+		shown = n - 1
+	}
+	return lines
+}
+
+func withDefaultInt(n, def int) int {
+	if n == 0 {
+		return def
+	}
+	return n
+}
+
+// messageText returns the plain text of s, or its Markdown if it has no text.
+func messageText(s *MultiformatMessageString) string {
+	if s == nil {
+		return ""
+	}
+	return withDefault(s.Text, s.Markdown)
+}
+
+// plainMessage returns the literal text of a message that is not a result
+// message, such as the description of a fix.
+func plainMessage(m *Message) string {
+	if m == nil {
+		return ""
+	}
+	return withDefault(m.Text, m.Markdown)
+}
+
+// anchorName turns s into a string usable in an HTML id. Letters, digits
+// and hyphens are kept and every other byte is written as an underscore
+// followed by its two hex digits, so that distinct strings, such as the ids
+// "a.b" and "a/b", never share an anchor.
+func anchorName(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "_%02X", c)
+	}
+	return b.String()
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"indent": func(n int) string { return strconv.Itoa(n * 2) },
+	"plural": plural,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 72em; padding: 0 1em; color: #1f2328; }
+h1, h2, h3 { font-weight: 600; }
+a { color: #0969da; }
+table.summary { border-collapse: collapse; margin: 0 2em 1em 0; display: inline-table; vertical-align: top; }
+table.summary th, table.summary td { border: 1px solid #d0d7de; padding: .25em .75em; text-align: left; }
+table.summary td.n { text-align: right; }
+section.rule { border-top: 1px solid #d0d7de; margin-top: 2em; }
+.result { border: 1px solid #d0d7de; border-radius: 6px; margin: 1em 0; padding: .5em 1em; }
+.level { display: inline-block; border-radius: 1em; padding: 0 .6em; font-size: .85em; font-weight: 600; color: #fff; background: #6e7781; }
+.level-error { background: #cf222e; }
+.level-warning { background: #9a6700; }
+.level-note { background: #0969da; }
+.tag { display: inline-block; border: 1px solid #d0d7de; border-radius: 1em; padding: 0 .6em; font-size: .85em; }
+.suppressed { opacity: .6; }
+.loc { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: .9em; }
+.message { white-space: pre-wrap; margin: .5em 0; }
+.help { white-space: pre-wrap; background: #f6f8fa; padding: .5em 1em; border-radius: 6px; }
+table.code { border-collapse: collapse; font-family: ui-monospace, Menlo, Consolas, monospace; font-size: .85em; width: 100%; background: #f6f8fa; }
+table.code td { padding: 0 .5em; white-space: pre; vertical-align: top; }
+table.code td.ln { color: #6e7781; text-align: right; width: 1%; user-select: none; }
+tr.hl { background: #fff8c5; }
+tr.del { background: #ffebe9; }
+tr.ins { background: #dafbe1; }
+ol.flow li { margin: .25em 0; }
+ol.flow li.unimportant { opacity: .6; }
+ol.flow li.essential { font-weight: 600; }
+details { margin: .5em 0; }
+summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Summary}}
+<p><strong>{{.Results}}</strong> {{plural .Results "result" "results"}} in {{len .Runs}} {{plural (len .Runs) "run" "runs"}}.</p>
+<table class="summary"><tr><th>Level</th><th>Results</th></tr>
+{{range $k, $v := .ByLevel}}<tr><td><span class="level level-{{$k}}">{{$k}}</span></td><td class="n">{{$v}}</td></tr>
+{{end}}</table>
+<table class="summary"><tr><th>Suppression</th><th>Results</th></tr>
+{{range $k, $v := .BySuppression}}<tr><td>{{$k}}</td><td class="n">{{$v}}</td></tr>
+{{end}}</table>
+{{if .TopRules}}<table class="summary"><tr><th>Rule</th><th>Results</th></tr>
+{{range .TopRules}}<tr><td>{{.Key}}</td><td class="n">{{.Count}}</td></tr>
+{{end}}</table>{{end}}
+{{if .TopFiles}}<table class="summary"><tr><th>File</th><th>Results</th></tr>
+{{range .TopFiles}}<tr><td class="loc">{{.Key}}</td><td class="n">{{.Count}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}
+{{range .Runs}}
+<h2>Run {{.Index}}{{if .Tool}}: {{.Tool}}{{end}}</h2>
+{{if .Rules}}<ul>
+{{range .Rules}}<li><a href="#{{.Anchor}}">{{.ID}}</a>{{if .Name}} {{.Name}}{{end}} ({{len .Results}})</li>
+{{end}}</ul>{{else}}<p>No results.</p>{{end}}
+{{range .Rules}}
+<section class="rule" id="{{.Anchor}}">
+<h3>{{.ID}}{{if .Name}}: {{.Name}}{{end}}{{if .Level}} <span class="level level-{{.Level}}">{{.Level}}</span>{{end}}</h3>
+{{if .Short}}<p>{{.Short}}</p>{{end}}
+{{if .Full}}<p>{{.Full}}</p>{{end}}
+{{if .Help}}<details><summary>Help</summary><div class="help">{{.Help}}</div></details>{{end}}
+{{if .HelpURI}}<p><a href="{{.HelpURI}}">{{.HelpURI}}</a></p>{{end}}
+{{range .Results}}{{template "result" .}}{{end}}
+</section>
+{{end}}
+{{end}}
+</body>
+</html>
+{{define "result"}}
+<div class="result{{if eq .Suppression "suppressed"}} suppressed{{end}}" id="{{.Anchor}}">
+<a href="#{{.Anchor}}">#</a>
+<span class="level level-{{.Level}}">{{.Level}}</span>
+{{if ne .Kind "fail"}}<span class="tag">{{.Kind}}</span>{{end}}
+{{if .Baseline}}<span class="tag">{{.Baseline}}</span>{{end}}
+{{if ne .Suppression "unsuppressed"}}<span class="tag" title="{{.Justification}}">{{.Suppression}}</span>{{end}}
+{{if .Location}}<span class="loc">{{.Location}}</span>{{end}}
+<div class="message">{{.Message}}</div>
+{{if .Snippet}}{{template "code" .Snippet}}{{end}}
+{{range $i, $f := .Flows}}
+<details open><summary>Code flow {{$i}}{{if .Message}}: {{.Message}}{{end}}</summary>
+{{range .Threads}}{{if or .ID .Message}}<p>Thread {{.ID}} {{.Message}}</p>{{end}}
+<ol class="flow">
+{{range .Steps}}<li id="{{.Anchor}}" class="{{.Importance}}" style="margin-left: {{indent .Nesting}}em"><a href="#{{.Anchor}}">{{.Number}}.</a> <span class="loc">{{.Location}}</span>{{if .Message}} {{.Message}}{{end}}{{if .Source}}<br><code>{{.Source}}</code>{{end}}</li>
+{{end}}</ol>
+{{end}}
+</details>
+{{end}}
+{{range $i, $s := .Stacks}}
+<details><summary>Stack {{$i}}{{if .Message}}: {{.Message}}{{end}}</summary>
+<ol>{{range .Frames}}<li class="loc">{{.}}</li>{{end}}</ol>
+</details>
+{{end}}
+{{range $i, $f := .Fixes}}
+<details><summary>Fix {{$i}}{{if .Description}}: {{.Description}}{{end}}</summary>
+{{range .Changes}}<p class="loc">{{.URI}}</p>{{if .Note}}<p>{{.Note}}</p>{{end}}{{template "code" .Lines}}{{end}}
+</details>
+{{end}}
+</div>
+{{end}}
+{{define "code"}}<table class="code">
+{{range .}}<tr{{if eq .Op "-"}} class="del"{{else if eq .Op "+"}} class="ins"{{else if .Highlight}} class="hl"{{end}}><td class="ln">{{if .Number}}{{.Number}}{{end}}</td><td>{{.Op}}{{.Text}}</td></tr>
+{{end}}</table>{{end}}
+`))
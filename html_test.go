@@ -0,0 +1,104 @@
+package sarif
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, readTestLog(t, "sample.sarif"), &HTMLOptions{ContextLines: 1}); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "report.html", buf.Bytes())
+}
+
+func TestHTMLSource(t *testing.T) {
+	contents := "package main\n\nfunc main() {\n\tembedded()\n}\n"
+	fetched := "line 1\nline 2\nfetched line\n"
+	tests := []struct {
+		name      string
+		loc       ArtifactLocation
+		line      int
+		want      string
+		wantFetch string
+	}{
+		{
+			name: "embedded contents by index",
+			loc:  ArtifactLocation{Uri: "cmd/main.go", Index: 0},
+			line: 4,
+			want: "embedded()",
+		},
+		{
+			name:      "index of another artifact",
+			loc:       ArtifactLocation{Uri: "other.go", Index: 0},
+			line:      3,
+			want:      "fetched line",
+			wantFetch: "other.go",
+		},
+		{
+			name:      "base id of another artifact",
+			loc:       ArtifactLocation{Uri: "cmd/main.go", UriBaseId: "OTHER", Index: 0},
+			line:      3,
+			want:      "fetched line",
+			wantFetch: "cmd/main.go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			log := &SARIF{Runs: []*Run{{
+				Tool:      &Tool{Driver: &ToolComponent{Name: "t"}},
+				Artifacts: []*Artifact{{Location: &ArtifactLocation{Uri: "cmd/main.go", Index: -1}, Contents: &ArtifactContent{Text: contents}}},
+				Results: []*Result{{
+					RuleId:  "R1",
+					Message: &Message{Text: "m"},
+					Locations: []*Location{{PhysicalLocation: &PhysicalLocation{
+						ArtifactLocation: &loc,
+						Region:           &Region{StartLine: tt.line},
+					}}},
+				}},
+			}}}
+			var requested []string
+			opts := &HTMLOptions{Source: func(uri string) ([]byte, error) {
+				requested = append(requested, uri)
+				return []byte(fetched), nil
+			}}
+			var buf bytes.Buffer
+			if err := WriteHTML(&buf, log, opts); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("report does not show %q", tt.want)
+			}
+			if tt.wantFetch == "" {
+				if len(requested) > 0 {
+					t.Errorf("Source called with %q, want no call", requested)
+				}
+				return
+			}
+			if strings.Contains(buf.String(), "embedded()") {
+				t.Error("report shows the contents of artifact 0")
+			}
+			if len(requested) != 1 || !strings.HasSuffix(requested[0], tt.wantFetch) {
+				t.Errorf("Source called with %q, want a uri ending in %q", requested, tt.wantFetch)
+			}
+		})
+	}
+}
+
+func TestAnchorName(t *testing.T) {
+	ids := []string{"a.b", "a/b", "a_b", "a_2Eb", "a-b", "CWE-79"}
+	seen := make(map[string]string)
+	for _, id := range ids {
+		a := anchorName(id)
+		if strings.Trim(a, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+			t.Errorf("anchorName(%q) = %q, which is not a plain HTML id", id, a)
+		}
+		if other, ok := seen[a]; ok {
+			t.Errorf("anchorName(%q) = anchorName(%q) = %q", id, other, a)
+		}
+		seen[a] = id
+	}
+}
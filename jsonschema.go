@@ -0,0 +1,681 @@
+package sarif
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrSchemaViolation is wrapped by the errors returned for documents that do
+// not conform to their schema.
+var ErrSchemaViolation = errors.New("document does not conform to its schema")
+
+// SchemaViolation is a place where a document does not conform to a JSON
+// schema.
+type SchemaViolation struct {
+	// Pointer is a JSON pointer to the offending value.
+	Pointer string
+	Detail  string
+}
+
+func (v *SchemaViolation) Error() string {
+	return fmt.Sprintf("%s: %s", withDefault(v.Pointer, "/"), v.Detail)
+}
+
+// violationsError returns an error that reports the first of violations of
+// the named document and counts the others.
+func violationsError(document string, violations []*SchemaViolation) error {
+	more := ""
+	if n := len(violations) - 1; n > 0 {
+		more = fmt.Sprintf(" (and %d more)", n)
+	}
+	return fmt.Errorf("%s: %w: %v%s", document, ErrSchemaViolation, violations[0], more)
+}
+
+// jsonSchema is a compiled JSON Schema draft 7 schema. Schemas are compiled
+// from their JSON by parseJSONSchema, which rejects keywords that it does not
+// know, so that a schema is never silently checked only in part.
+type jsonSchema struct {
+	// always is the outcome of the boolean schemas true and false.
+	always *bool
+
+	ref    string
+	target *jsonSchema
+
+	types    []string
+	enum     []interface{}
+	constant *interface{}
+
+	// strings
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	format               string
+
+	// numbers
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+	multipleOf                         *float64
+
+	// arrays
+	items           *jsonSchema
+	tupleItems      []*jsonSchema
+	additionalItems *jsonSchema
+	contains        *jsonSchema
+	minItems        *int
+	maxItems        *int
+	uniqueItems     bool
+	hasTupleItems   bool
+
+	// objects
+	required             []string
+	properties           map[string]*jsonSchema
+	patternProperties    []*jsonSchemaPattern
+	additionalProperties *jsonSchema
+	propertyNames        *jsonSchema
+	dependencies         map[string]*jsonSchemaDependency
+	minProperties        *int
+	maxProperties        *int
+
+	// combinators
+	allOf, anyOf, oneOf []*jsonSchema
+	not                 *jsonSchema
+	ifSchema            *jsonSchema
+	thenSchema          *jsonSchema
+	elseSchema          *jsonSchema
+}
+
+type jsonSchemaPattern struct {
+	re     *regexp.Regexp
+	schema *jsonSchema
+}
+
+// jsonSchemaDependency is a value of "dependencies": the properties that a
+// property requires, or a schema that an object with the property must
+// conform to.
+type jsonSchemaDependency struct {
+	properties []string
+	schema     *jsonSchema
+}
+
+// jsonSchemaAnnotations are the keywords that do not constrain documents. Of
+// these, "self" is not part of JSON Schema: GitLab's report schemas record
+// their version in it.
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "readOnly": true, "writeOnly": true,
+	"contentEncoding": true, "contentMediaType": true, "self": true,
+}
+
+// jsonSchemaFormats checks the values of the formats that a schema may use.
+var jsonSchemaFormats = map[string]func(s string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05Z07:00", s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		at := strings.LastIndexByte(s, '@')
+		return at > 0 && at < len(s)-1
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+}
+
+// parseJSONSchema compiles the schema in data. It fails on keywords, formats
+// and references it does not support, and on patterns that are not valid Go
+// regular expressions.
+func parseJSONSchema(data []byte) (*jsonSchema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	c := &jsonSchemaCompiler{byPointer: make(map[string]*jsonSchema)}
+	root, err := c.compile(doc, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range c.refs {
+		target := c.byPointer[strings.TrimPrefix(s.ref, "#")]
+		if target == nil {
+			return nil, fmt.Errorf("unsupported or unknown reference %q", s.ref)
+		}
+		s.target = target
+	}
+	// a chain of references that leads back to itself would never end
+	for _, s := range c.refs {
+		seen := map[*jsonSchema]bool{}
+		for t := s; t.target != nil; t = t.target {
+			if seen[t] {
+				return nil, fmt.Errorf("reference %q is circular", s.ref)
+			}
+			seen[t] = true
+		}
+	}
+	return root, nil
+}
+
+type jsonSchemaCompiler struct {
+	byPointer map[string]*jsonSchema
+	refs      []*jsonSchema
+}
+
+// compile compiles the schema v found at pointer in the schema document.
+func (c *jsonSchemaCompiler) compile(v interface{}, pointer string) (*jsonSchema, error) {
+	s := &jsonSchema{}
+	c.byPointer[pointer] = s
+	if b, ok := v.(bool); ok {
+		s.always = &b
+		return s, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema is %s, not an object or boolean", withDefault(pointer, "/"), jsonTypeOf(v))
+	}
+	fail := func(keyword, format string, args ...interface{}) error {
+		return fmt.Errorf("%s/%s: %s", pointer, escapePointer(keyword), fmt.Sprintf(format, args...))
+	}
+	sub := func(keyword string, v interface{}) (*jsonSchema, error) {
+		return c.compile(v, pointer+"/"+escapePointer(keyword))
+	}
+	list := func(keyword string, v interface{}) ([]*jsonSchema, error) {
+		items, ok := v.([]interface{})
+		if !ok || len(items) == 0 {
+			return nil, fail(keyword, "not a non-empty array of schemas")
+		}
+		var schemas []*jsonSchema
+		for i, item := range items {
+			s, err := c.compile(item, fmt.Sprintf("%s/%s/%d", pointer, escapePointer(keyword), i))
+			if err != nil {
+				return nil, err
+			}
+			schemas = append(schemas, s)
+		}
+		return schemas, nil
+	}
+	count := func(keyword string, v interface{}) (*int, error) {
+		f, ok := v.(float64)
+		if !ok || f < 0 || f != math.Trunc(f) {
+			return nil, fail(keyword, "not a non-negative integer")
+		}
+		n := int(f)
+		return &n, nil
+	}
+	number := func(keyword string, v interface{}) (*float64, error) {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fail(keyword, "not a number")
+		}
+		return &f, nil
+	}
+	strs := func(keyword string, v interface{}) ([]string, error) {
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, fail(keyword, "not an array of strings")
+		}
+		var out []string
+		for _, item := range items {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fail(keyword, "not an array of strings")
+			}
+			out = append(out, str)
+		}
+		return out, nil
+	}
+	regex := func(keyword, expr string) (*regexp.Regexp, error) {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fail(keyword, "unsupported pattern: %v", err)
+		}
+		return re, nil
+	}
+
+	keywords := make([]string, 0, len(m))
+	for k := range m {
+		keywords = append(keywords, k)
+	}
+	sort.Strings(keywords)
+	var err error
+	for _, k := range keywords {
+		v := m[k]
+		switch k {
+		case "$id":
+			if pointer != "" {
+				return nil, fail(k, "unsupported: only the root schema may have an $id")
+			}
+		case "definitions":
+			defs, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fail(k, "not an object")
+			}
+			for name, def := range defs {
+				if _, err := c.compile(def, pointer+"/definitions/"+escapePointer(name)); err != nil {
+					return nil, err
+				}
+			}
+		case "$ref":
+			ref, ok := v.(string)
+			if !ok || !strings.HasPrefix(ref, "#") {
+				return nil, fail(k, "unsupported reference %v: only references within the schema are", v)
+			}
+			s.ref = ref
+			c.refs = append(c.refs, s)
+		case "type":
+			switch t := v.(type) {
+			case string:
+				s.types = []string{t}
+			default:
+				if s.types, err = strs(k, v); err != nil {
+					return nil, err
+				}
+			}
+			for _, t := range s.types {
+				switch t {
+				case "null", "boolean", "object", "array", "number", "integer", "string":
+				default:
+					return nil, fail(k, "unknown type %q", t)
+				}
+			}
+		case "enum":
+			enum, ok := v.([]interface{})
+			if !ok {
+				return nil, fail(k, "not an array")
+			}
+			s.enum = enum
+		case "const":
+			v := v
+			s.constant = &v
+		case "minLength":
+			s.minLength, err = count(k, v)
+		case "maxLength":
+			s.maxLength, err = count(k, v)
+		case "pattern":
+			expr, ok := v.(string)
+			if !ok {
+				return nil, fail(k, "not a string")
+			}
+			s.pattern, err = regex(k, expr)
+		case "format":
+			format, ok := v.(string)
+			if !ok {
+				return nil, fail(k, "not a string")
+			}
+			if jsonSchemaFormats[format] == nil {
+				return nil, fail(k, "unsupported format %q", format)
+			}
+			s.format = format
+		case "minimum":
+			s.minimum, err = number(k, v)
+		case "maximum":
+			s.maximum, err = number(k, v)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = number(k, v)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = number(k, v)
+		case "multipleOf":
+			if s.multipleOf, err = number(k, v); err == nil && *s.multipleOf <= 0 {
+				err = fail(k, "not a positive number")
+			}
+		case "items":
+			if _, ok := v.([]interface{}); ok {
+				s.tupleItems, err = list(k, v)
+				s.hasTupleItems = true
+			} else {
+				s.items, err = sub(k, v)
+			}
+		case "additionalItems":
+			s.additionalItems, err = sub(k, v)
+		case "contains":
+			s.contains, err = sub(k, v)
+		case "minItems":
+			s.minItems, err = count(k, v)
+		case "maxItems":
+			s.maxItems, err = count(k, v)
+		case "uniqueItems":
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fail(k, "not a boolean")
+			}
+			s.uniqueItems = b
+		case "required":
+			s.required, err = strs(k, v)
+		case "properties":
+			props, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fail(k, "not an object")
+			}
+			s.properties = make(map[string]*jsonSchema, len(props))
+			for name, prop := range props {
+				if s.properties[name], err = c.compile(prop, pointer+"/properties/"+escapePointer(name)); err != nil {
+					return nil, err
+				}
+			}
+		case "patternProperties":
+			props, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fail(k, "not an object")
+			}
+			for _, expr := range sortedKeys(props) {
+				re, err := regex(k, expr)
+				if err != nil {
+					return nil, err
+				}
+				schema, err := c.compile(props[expr], pointer+"/patternProperties/"+escapePointer(expr))
+				if err != nil {
+					return nil, err
+				}
+				s.patternProperties = append(s.patternProperties, &jsonSchemaPattern{re: re, schema: schema})
+			}
+		case "additionalProperties":
+			s.additionalProperties, err = sub(k, v)
+		case "propertyNames":
+			s.propertyNames, err = sub(k, v)
+		case "dependencies":
+			deps, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fail(k, "not an object")
+			}
+			s.dependencies = make(map[string]*jsonSchemaDependency, len(deps))
+			for name, dep := range deps {
+				d := &jsonSchemaDependency{}
+				if _, ok := dep.([]interface{}); ok {
+					d.properties, err = strs(k, dep)
+				} else {
+					d.schema, err = c.compile(dep, pointer+"/dependencies/"+escapePointer(name))
+				}
+				if err != nil {
+					return nil, err
+				}
+				s.dependencies[name] = d
+			}
+		case "minProperties":
+			s.minProperties, err = count(k, v)
+		case "maxProperties":
+			s.maxProperties, err = count(k, v)
+		case "allOf":
+			s.allOf, err = list(k, v)
+		case "anyOf":
+			s.anyOf, err = list(k, v)
+		case "oneOf":
+			s.oneOf, err = list(k, v)
+		case "not":
+			s.not, err = sub(k, v)
+		case "if":
+			s.ifSchema, err = sub(k, v)
+		case "then":
+			s.thenSchema, err = sub(k, v)
+		case "else":
+			s.elseSchema, err = sub(k, v)
+		default:
+			if !jsonSchemaAnnotations[k] {
+				return nil, fail(k, "unsupported keyword %q", k)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// validate checks the decoded JSON value v against s and returns the
+// violations it finds.
+func (s *jsonSchema) validate(v interface{}, pointer string) []*SchemaViolation {
+	if s.always != nil {
+		if *s.always {
+			return nil
+		}
+		return []*SchemaViolation{{Pointer: pointer, Detail: "no value is allowed here"}}
+	}
+	// in draft 7 the keywords next to a reference are ignored
+	if s.target != nil {
+		return s.target.validate(v, pointer)
+	}
+	violate := func(format string, args ...interface{}) []*SchemaViolation {
+		return []*SchemaViolation{{Pointer: pointer, Detail: fmt.Sprintf(format, args...)}}
+	}
+	if len(s.types) > 0 && !containsFunc(s.types, func(t string) bool { return jsonTypeMatches(t, v) }) {
+		return violate("expected %s, found %s", strings.Join(s.types, " or "), jsonTypeOf(v))
+	}
+	if s.enum != nil && !containsFunc(s.enum, func(e interface{}) bool { return reflect.DeepEqual(e, v) }) {
+		return violate("%v is not one of %v", v, s.enum)
+	}
+	if s.constant != nil && !reflect.DeepEqual(*s.constant, v) {
+		return violate("%v is not %v", v, *s.constant)
+	}
+
+	var violations []*SchemaViolation
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.minLength != nil && n < *s.minLength {
+			violations = append(violations, violate("string shorter than %d", *s.minLength)...)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			violations = append(violations, violate("string longer than %d", *s.maxLength)...)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			violations = append(violations, violate("%q does not match %s", v, s.pattern)...)
+		}
+		if s.format != "" && !jsonSchemaFormats[s.format](v) {
+			violations = append(violations, violate("%q is not a valid %s", v, s.format)...)
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			violations = append(violations, violate("%v is less than %v", v, *s.minimum)...)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			violations = append(violations, violate("%v is greater than %v", v, *s.maximum)...)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			violations = append(violations, violate("%v is not greater than %v", v, *s.exclusiveMinimum)...)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			violations = append(violations, violate("%v is not less than %v", v, *s.exclusiveMaximum)...)
+		}
+		if s.multipleOf != nil {
+			if q := v / *s.multipleOf; q != math.Trunc(q) {
+				violations = append(violations, violate("%v is not a multiple of %v", v, *s.multipleOf)...)
+			}
+		}
+	case []interface{}:
+		violations = append(violations, s.validateArray(v, pointer)...)
+	case map[string]interface{}:
+		violations = append(violations, s.validateObject(v, pointer)...)
+	}
+
+	for _, sub := range s.allOf {
+		violations = append(violations, sub.validate(v, pointer)...)
+	}
+	if s.anyOf != nil && !containsFunc(s.anyOf, func(sub *jsonSchema) bool { return sub.accepts(v) }) {
+		violations = append(violations, violate("value matches none of the schemas of anyOf")...)
+	}
+	if s.oneOf != nil {
+		matches := 0
+		for _, sub := range s.oneOf {
+			if sub.accepts(v) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			violations = append(violations, violate("value matches %d of the schemas of oneOf, not exactly one", matches)...)
+		}
+	}
+	if s.not != nil && s.not.accepts(v) {
+		violations = append(violations, violate("value matches the schema of not")...)
+	}
+	if s.ifSchema != nil {
+		if s.ifSchema.accepts(v) {
+			if s.thenSchema != nil {
+				violations = append(violations, s.thenSchema.validate(v, pointer)...)
+			}
+		} else if s.elseSchema != nil {
+			violations = append(violations, s.elseSchema.validate(v, pointer)...)
+		}
+	}
+	return violations
+}
+
+// accepts reports whether v conforms to s.
+func (s *jsonSchema) accepts(v interface{}) bool {
+	return len(s.validate(v, "")) == 0
+}
+
+func (s *jsonSchema) validateArray(v []interface{}, pointer string) []*SchemaViolation {
+	var violations []*SchemaViolation
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, &SchemaViolation{Pointer: pointer, Detail: fmt.Sprintf(format, args...)})
+	}
+	if s.minItems != nil && len(v) < *s.minItems {
+		violate("fewer than %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(v) > *s.maxItems {
+		violate("more than %d items", *s.maxItems)
+	}
+	if s.uniqueItems {
+	unique:
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if reflect.DeepEqual(v[i], v[j]) {
+					violate("items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+	for i, item := range v {
+		var sub *jsonSchema
+		switch {
+		case s.items != nil:
+			sub = s.items
+		case i < len(s.tupleItems):
+			sub = s.tupleItems[i]
+		case s.hasTupleItems:
+			sub = s.additionalItems
+		}
+		if sub != nil {
+			violations = append(violations, sub.validate(item, fmt.Sprintf("%s/%d", pointer, i))...)
+		}
+	}
+	if s.contains != nil && !containsFunc(v, s.contains.accepts) {
+		violate("no item matches the schema of contains")
+	}
+	return violations
+}
+
+func (s *jsonSchema) validateObject(v map[string]interface{}, pointer string) []*SchemaViolation {
+	var violations []*SchemaViolation
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, &SchemaViolation{Pointer: pointer, Detail: fmt.Sprintf(format, args...)})
+	}
+	for _, name := range s.required {
+		if _, ok := v[name]; !ok {
+			violate("missing required property %q", name)
+		}
+	}
+	if s.minProperties != nil && len(v) < *s.minProperties {
+		violate("fewer than %d properties", *s.minProperties)
+	}
+	if s.maxProperties != nil && len(v) > *s.maxProperties {
+		violate("more than %d properties", *s.maxProperties)
+	}
+	for _, k := range sortedKeys(v) {
+		at := pointer + "/" + escapePointer(k)
+		if s.propertyNames != nil && !s.propertyNames.accepts(k) {
+			violate("property name %q does not match the schema of propertyNames", k)
+		}
+		if d := s.dependencies[k]; d != nil {
+			for _, name := range d.properties {
+				if _, ok := v[name]; !ok {
+					violate("property %q requires property %q", k, name)
+				}
+			}
+			if d.schema != nil {
+				violations = append(violations, d.schema.validate(v, pointer)...)
+			}
+		}
+		matched := false
+		if sub := s.properties[k]; sub != nil {
+			matched = true
+			violations = append(violations, sub.validate(v[k], at)...)
+		}
+		for _, p := range s.patternProperties {
+			if p.re.MatchString(k) {
+				matched = true
+				violations = append(violations, p.schema.validate(v[k], at)...)
+			}
+		}
+		if !matched && s.additionalProperties != nil {
+			if s.additionalProperties.always != nil && !*s.additionalProperties.always {
+				violate("unexpected property %q", k)
+				continue
+			}
+			violations = append(violations, s.additionalProperties.validate(v[k], at)...)
+		}
+	}
+	return violations
+}
+
+func jsonTypeMatches(t string, v interface{}) bool {
+	switch t {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	}
+	return jsonTypeOf(v) == t
+}
+
+func jsonTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func containsFunc[T any](list []T, f func(T) bool) bool {
+	for _, e := range list {
+		if f(e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package sarif
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		valid   []string
+		invalid []string
+	}{
+		{"boolean true", `true`, []string{`1`, `null`}, nil},
+		{"boolean false", `false`, nil, []string{`1`, `null`}},
+		{"type", `{"type": ["integer", "null"]}`, []string{`1`, `null`}, []string{`1.5`, `"1"`}},
+		{"enum", `{"enum": ["a", 1, {"b": [2]}]}`, []string{`"a"`, `1`, `{"b": [2]}`}, []string{`"b"`, `{"b": [3]}`}},
+		{"const", `{"const": {"a": 1}}`, []string{`{"a": 1}`}, []string{`{"a": 2}`}},
+		{"string lengths", `{"minLength": 2, "maxLength": 3}`, []string{`"éé"`, `"abc"`, `5`}, []string{`"a"`, `"abcd"`}},
+		{"pattern", `{"pattern": "^[a-z]+$"}`, []string{`"abc"`}, []string{`"ABC"`}},
+		{"format", `{"format": "date-time"}`, []string{`"2024-05-01T10:00:00Z"`}, []string{`"2024-05-01"`}},
+		{"uri format", `{"format": "uri"}`, []string{`"https://example.com"`}, []string{`"example.com"`}},
+		{"number bounds", `{"minimum": 1, "maximum": 3, "multipleOf": 0.5}`, []string{`1`, `2.5`, `3`}, []string{`0`, `3.5`, `1.2`}},
+		{"exclusive bounds", `{"exclusiveMinimum": 1, "exclusiveMaximum": 3}`, []string{`2`}, []string{`1`, `3`}},
+		{"items", `{"items": {"type": "string"}, "minItems": 1, "maxItems": 2}`, []string{`["a"]`, `["a", "b"]`}, []string{`[]`, `[1]`, `["a", "b", "c"]`}},
+		{"tuple items", `{"items": [{"type": "string"}, {"type": "number"}], "additionalItems": false}`, []string{`["a"]`, `["a", 1]`}, []string{`[1]`, `["a", 1, 2]`}},
+		{"unique items", `{"uniqueItems": true}`, []string{`[1, 2]`}, []string{`[{"a": 1}, {"a": 1}]`}},
+		{"contains", `{"contains": {"const": 2}}`, []string{`[1, 2]`}, []string{`[1]`, `[]`}},
+		{"required", `{"required": ["a"]}`, []string{`{"a": null}`, `"not an object"`}, []string{`{}`}},
+		{"properties", `{"properties": {"a": {"type": "string"}}}`, []string{`{"a": "x", "b": 1}`}, []string{`{"a": 1}`}},
+		{"additional properties", `{"properties": {"a": true}, "patternProperties": {"^x-": true}, "additionalProperties": false}`, []string{`{"a": 1, "x-b": 2}`}, []string{`{"b": 1}`}},
+		{"additional property schema", `{"additionalProperties": {"type": "number"}}`, []string{`{"a": 1}`}, []string{`{"a": "1"}`}},
+		{"pattern properties", `{"patternProperties": {"^n": {"type": "number"}}}`, []string{`{"n1": 1, "s": "x"}`}, []string{`{"n1": "x"}`}},
+		{"property names", `{"propertyNames": {"maxLength": 2}}`, []string{`{"ab": 1}`}, []string{`{"abc": 1}`}},
+		{"property counts", `{"minProperties": 1, "maxProperties": 1}`, []string{`{"a": 1}`}, []string{`{}`, `{"a": 1, "b": 2}`}},
+		{"property dependencies", `{"dependencies": {"a": ["b"]}}`, []string{`{"a": 1, "b": 2}`, `{"b": 2}`}, []string{`{"a": 1}`}},
+		{"schema dependencies", `{"dependencies": {"a": {"required": ["c"]}}}`, []string{`{"a": 1, "c": 2}`}, []string{`{"a": 1}`}},
+		{"allOf", `{"allOf": [{"minimum": 1}, {"maximum": 2}]}`, []string{`1`, `2`}, []string{`0`, `3`}},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"minimum": 1}]}`, []string{`"a"`, `1`}, []string{`0`}},
+		{"oneOf", `{"oneOf": [{"minimum": 1}, {"maximum": 2}]}`, []string{`0`, `3`}, []string{`1.5`}},
+		{"not", `{"not": {"type": "string"}}`, []string{`1`}, []string{`"a"`}},
+		{"if then else", `{"if": {"minimum": 10}, "then": {"multipleOf": 10}, "else": {"maximum": 5}}`, []string{`20`, `5`}, []string{`15`, `7`}},
+		{"definitions and refs", `{"definitions": {"pos": {"minimum": 1}}, "properties": {"a": {"$ref": "#/definitions/pos"}}}`, []string{`{"a": 1}`}, []string{`{"a": 0}`}},
+		{"ref into properties", `{"properties": {"a": {"type": "string"}, "b": {"$ref": "#/properties/a"}}}`, []string{`{"b": "x"}`}, []string{`{"b": 1}`}},
+		{"recursive ref", `{"properties": {"next": {"$ref": "#"}}, "required": ["v"]}`, []string{`{"v": 1, "next": {"v": 2}}`}, []string{`{"v": 1, "next": {}}`}},
+		{"keywords next to a ref are ignored", `{"definitions": {"s": {"type": "string"}}, "properties": {"a": {"$ref": "#/definitions/s", "minLength": 5}}}`, []string{`{"a": "x"}`}, []string{`{"a": 1}`}},
+		{"annotations", `{"$schema": "http://json-schema.org/draft-07/schema#", "$id": "https://example.com/s", "title": "t", "description": "d", "default": 1, "examples": [1], "$comment": "c", "self": {"version": "1.0.0"}}`, []string{`1`}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseJSONSchema([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			check := func(doc string, valid bool) {
+				var v interface{}
+				if err := json.Unmarshal([]byte(doc), &v); err != nil {
+					t.Fatal(err)
+				}
+				if violations := s.validate(v, ""); (len(violations) == 0) != valid {
+					t.Errorf("validate(%s) = %v, want valid = %v", doc, violations, valid)
+				}
+			}
+			for _, doc := range tt.valid {
+				check(doc, true)
+			}
+			for _, doc := range tt.invalid {
+				check(doc, false)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"unknown keyword", `{"properties": {"a": {"typ": "string"}}}`, `/properties/a/typ: unsupported keyword "typ"`},
+		{"later draft keyword", `{"$defs": {}}`, `unsupported keyword "$defs"`},
+		{"unknown format", `{"format": "hostname"}`, `unsupported format "hostname"`},
+		{"unknown type", `{"type": "int"}`, `unknown type "int"`},
+		{"lookahead pattern", `{"pattern": "^(?!x)"}`, `unsupported pattern`},
+		{"unknown definition", `{"$ref": "#/definitions/missing"}`, `unknown reference "#/definitions/missing"`},
+		{"remote reference", `{"$ref": "https://example.com/s.json"}`, `only references within the schema`},
+		{"circular references", `{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}}`, `is circular`},
+		{"nested id", `{"properties": {"a": {"$id": "x"}}}`, `only the root schema may have an $id`},
+		{"schema of the wrong type", `{"items": 1}`, `schema is number`},
+		{"negative count", `{"minItems": -1}`, `not a non-negative integer`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJSONSchema([]byte(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseJSONSchema() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package sarif
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JUnitCaseStrategy states what WriteJUnit turns into test cases.
+type JUnitCaseStrategy int
+
+const (
+	// RuleCases writes a test case per rule, which fails if any of the
+	// rule's results do. Rules of the driver without results are written as
+	// passing test cases.
+	RuleCases JUnitCaseStrategy = iota
+	// ResultCases writes a test case per result.
+	ResultCases
+)
+
+// JUnitOptions configures WriteJUnit.
+type JUnitOptions struct {
+	// Name is the name of the test suites; "sarif" if empty.
+	Name string
+	// Cases states what is turned into test cases.
+	Cases JUnitCaseStrategy
+	// FailureLevels are the effective levels of failing results; "error" and
+	// "warning" if empty. Suppressed results never fail.
+	FailureLevels []string
+}
+
+type junitSuites struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Errors   int           `xml:"errors,attr"`
+	Skipped  int           `xml:"skipped,attr"`
+	Time     string        `xml:"time,attr,omitempty"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr,omitempty"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Hostname   string           `xml:"hostname,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Cases      []*junitCase     `xml:"testcase"`
+	SystemErr  *junitText       `xml:"system-err,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []*junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+	SystemOut *junitText    `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+type junitText struct {
+	Text string `xml:",cdata"`
+}
+
+func newJUnitText(s string) *junitText {
+	if s == "" {
+		return nil
+	}
+	return &junitText{Text: s}
+}
+
+// WriteJUnit writes log to w as a JUnit XML report, for consumers that only
+// understand test results. Each run becomes a <testsuite> named after its
+// tool, with test cases per rule or per result depending on opts.Cases.
+// Failing results become <failure> elements that give their message and
+// location, and suppressed results are reported as skipped when test cases
+// are per result.
+//
+// The duration and start time of a suite are taken from the invocations of
+// the run, an invocation that did not succeed is reported as an <error> test
+// case, and the tool execution and configuration notifications of the
+// invocations are written to <system-err>. opts may be nil.
+func WriteJUnit(w io.Writer, log *SARIF, opts *JUnitOptions) error {
+	if opts == nil {
+		opts = &JUnitOptions{}
+	}
+	failureLevels := opts.FailureLevels
+	if len(failureLevels) == 0 {
+		failureLevels = []string{"error", "warning"}
+	}
+	suites := &junitSuites{Name: withDefault(opts.Name, "sarif")}
+	var total time.Duration
+	timed := false
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		suite := newJUnitSuite(run)
+		if opts.Cases == ResultCases {
+			suite.Cases = append(suite.Cases, junitResultCases(run, failureLevels)...)
+		} else {
+			suite.Cases = append(suite.Cases, junitRuleCases(run, failureLevels)...)
+		}
+		for _, c := range suite.Cases {
+			suite.Tests++
+			switch {
+			case c.Error != nil:
+				suite.Errors++
+			case c.Failure != nil:
+				suite.Failures++
+			case c.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+		if d, ok := runDuration(run); ok {
+			suite.Time = junitSeconds(d)
+			total += d
+			timed = true
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	if timed {
+		suites.Time = junitSeconds(total)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// newJUnitSuite returns the suite of run, with the test cases and notes
+// derived from its invocations.
+func newJUnitSuite(run *Run) *junitSuite {
+	suite := &junitSuite{Name: "(unknown tool)"}
+	if run.Tool != nil && run.Tool.Driver != nil {
+		driver := run.Tool.Driver
+		suite.Name = withDefault(driver.FullName, withDefault(driver.Name, suite.Name))
+		if v := withDefault(driver.SemanticVersion, driver.Version); v != "" {
+			suite.addProperty("tool.version", v)
+		}
+	}
+	var stderr strings.Builder
+	for i, inv := range run.Invocations {
+		if inv == nil {
+			continue
+		}
+		if i == 0 {
+			suite.Timestamp = inv.StartTimeUtc
+			suite.Hostname = inv.Machine
+		}
+		if inv.CommandLine != "" {
+			suite.addProperty("invocation."+strconv.Itoa(i)+".commandLine", inv.CommandLine)
+		}
+		if !inv.ExecutionSuccessful {
+			suite.Cases = append(suite.Cases, &junitCase{
+				Name:      "invocation " + strconv.Itoa(i),
+				Classname: suite.Name,
+				Time:      junitSeconds(invocationDuration(inv)),
+				Error:     &junitProblem{Message: invocationFailure(inv), Type: "execution"},
+			})
+		}
+		for _, n := range append(append([]*Notification(nil), inv.ToolExecutionNotifications...), inv.ToolConfigurationNotifications...) {
+			if n != nil {
+				stderr.WriteString(describeNotification(run, n) + "\n")
+			}
+		}
+	}
+	suite.SystemErr = newJUnitText(stderr.String())
+	return suite
+}
+
+func (s *junitSuite) addProperty(name, value string) {
+	if s.Properties == nil {
+		s.Properties = &junitProperties{}
+	}
+	s.Properties.Properties = append(s.Properties.Properties, &junitProperty{Name: name, Value: value})
+}
+
+// junitRuleCases returns a test case per rule of run: first the rules of the
+// driver, then the rules that only results refer to, in order of appearance.
+func junitRuleCases(run *Run, failureLevels []string) []*junitCase {
+	type ruleCase struct {
+		id       string
+		rule     *ReportingDescriptor
+		failures []*Result
+		passes   int
+	}
+	var cases []*ruleCase
+	byID := make(map[string]*ruleCase)
+	add := func(id string, rule *ReportingDescriptor) *ruleCase {
+		c := byID[id]
+		if c == nil {
+			c = &ruleCase{id: id, rule: rule}
+			byID[id] = c
+			cases = append(cases, c)
+		}
+		return c
+	}
+	if run.Tool != nil && run.Tool.Driver != nil {
+		for _, rule := range run.Tool.Driver.Rules {
+			if rule != nil {
+				add(rule.Id, rule)
+			}
+		}
+	}
+	for _, result := range run.Results {
+		if result == nil {
+			continue
+		}
+		id, rule := run.ruleID(result), run.rule(result)
+		if rule != nil && rule.Id != "" {
+			// a hierarchical id such as "R1/sub" counts towards its rule
+			id = rule.Id
+		}
+		c := add(id, rule)
+		if junitFails(run, result, failureLevels) {
+			c.failures = append(c.failures, result)
+		} else {
+			c.passes++
+		}
+	}
+
+	classname := junitClassname(run)
+	out := make([]*junitCase, len(cases))
+	for i, c := range cases {
+		tc := &junitCase{Name: withDefault(c.id, "(no rule)"), Classname: classname, Time: "0"}
+		if c.rule != nil && c.rule.ShortDescription != nil {
+			tc.Name += ": " + messageText(c.rule.ShortDescription)
+		}
+		if len(c.failures) > 0 {
+			var text strings.Builder
+			for _, result := range c.failures {
+				text.WriteString(describeMatch(Match{Run: run, Result: result}) + "\n")
+			}
+			tc.Failure = &junitProblem{
+				Message: fmt.Sprintf("%d %s", len(c.failures), plural(len(c.failures), "result", "results")),
+				Type:    run.EffectiveLevel(c.failures[0]),
+				Text:    text.String(),
+			}
+		}
+		if c.passes > 0 {
+			tc.SystemOut = newJUnitText(fmt.Sprintf("%d non-failing %s", c.passes, plural(c.passes, "result", "results")))
+		}
+		out[i] = tc
+	}
+	return out
+}
+
+// junitResultCases returns a test case per result of run.
+func junitResultCases(run *Run, failureLevels []string) []*junitCase {
+	var cases []*junitCase
+	for i, result := range run.Results {
+		if result == nil {
+			continue
+		}
+		id := withDefault(run.ruleID(result), "(no rule)")
+		tc := &junitCase{Name: "result " + strconv.Itoa(i), Classname: id, Time: "0"}
+		if pl := primaryLocation(result); pl != nil {
+			tc.Name = run.displayURI(pl.ArtifactLocation)
+			if pl.Region != nil && pl.Region.StartLine > 0 {
+				tc.Name += ":" + strconv.Itoa(pl.Region.StartLine)
+				if pl.Region.StartColumn > 0 {
+					tc.Name += ":" + strconv.Itoa(pl.Region.StartColumn)
+				}
+			}
+		}
+		msg, err := run.FormatMessage(result, false)
+		if err != nil {
+			msg = "(" + err.Error() + ")"
+		}
+		switch {
+		case result.IsSuppressed():
+			var js []string
+			for _, s := range result.Suppressions {
+				if s != nil && s.Justification != "" {
+					js = append(js, s.Justification)
+				}
+			}
+			tc.Skipped = &junitProblem{Message: withDefault(strings.Join(js, "; "), "suppressed")}
+		case junitFails(run, result, failureLevels):
+			tc.Failure = &junitProblem{
+				Message: strings.SplitN(msg, "\n", 2)[0],
+				Type:    run.EffectiveLevel(result),
+				Text:    describeMatch(Match{Run: run, Result: result}) + "\n",
+			}
+		default:
+			tc.SystemOut = newJUnitText(msg)
+		}
+		cases = append(cases, tc)
+	}
+	return cases
+}
+
+func junitFails(run *Run, result *Result, failureLevels []string) bool {
+	return !result.IsSuppressed() && contains(failureLevels, run.EffectiveLevel(result))
+}
+
+func junitClassname(run *Run) string {
+	if run.Tool != nil && run.Tool.Driver != nil && run.Tool.Driver.Name != "" {
+		return run.Tool.Driver.Name
+	}
+	return "sarif"
+}
+
+// runDuration returns the total duration of the invocations of run that
+// record both their start and end time.
+func runDuration(run *Run) (time.Duration, bool) {
+	var total time.Duration
+	ok := false
+	for _, inv := range run.Invocations {
+		if inv == nil {
+			continue
+		}
+		start, err1 := time.Parse(time.RFC3339, inv.StartTimeUtc)
+		end, err2 := time.Parse(time.RFC3339, inv.EndTimeUtc)
+		if err1 == nil && err2 == nil && !end.Before(start) {
+			total += end.Sub(start)
+			ok = true
+		}
+	}
+	return total, ok
+}
+
+func invocationDuration(inv *Invocation) time.Duration {
+	d, _ := runDuration(&Run{Invocations: []*Invocation{inv}})
+	return d
+}
+
+// invocationFailure describes why an invocation did not succeed.
+func invocationFailure(inv *Invocation) string {
+	switch {
+	case inv.ProcessStartFailureMessage != "":
+		return inv.ProcessStartFailureMessage
+	case inv.ExitSignalName != "":
+		return "terminated by signal " + inv.ExitSignalName
+	case inv.ExitCodeDescription != "":
+		return inv.ExitCodeDescription
+	case inv.ExitCode != 0:
+		return "exit code " + strconv.Itoa(inv.ExitCode)
+	}
+	return "execution did not succeed"
+}
+
+// describeNotification describes a notification as
+// "uri:line: level descriptor: message".
+func describeNotification(run *Run, n *Notification) string {
+	var b strings.Builder
+	for _, loc := range n.Locations {
+		if loc != nil && loc.PhysicalLocation != nil && loc.PhysicalLocation.ArtifactLocation != nil {
+			pl := loc.PhysicalLocation
+			b.WriteString(run.displayURI(pl.ArtifactLocation))
+			if pl.Region != nil && pl.Region.StartLine > 0 {
+				fmt.Fprintf(&b, ":%d", pl.Region.StartLine)
+			}
+			b.WriteString(": ")
+			break
+		}
+	}
+	b.WriteString(withDefault(n.Level, "warning"))
+	if n.Descriptor != nil && n.Descriptor.Id != "" {
+		b.WriteString(" " + n.Descriptor.Id)
+	}
+	if text := plainMessage(n.Message); text != "" {
+		b.WriteString(": " + text)
+	}
+	if n.Exception != nil && n.Exception.Message != "" {
+		b.WriteString(" (" + withDefault(n.Exception.Kind, "exception") + ": " + n.Exception.Message + ")")
+	}
+	return b.String()
+}
+
+func junitSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
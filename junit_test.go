@@ -0,0 +1,107 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	tests := []struct {
+		name   string
+		golden string
+		opts   *JUnitOptions
+	}{
+		{"rule cases", "junit-rules.xml", nil},
+		{"result cases", "junit-results.xml", &JUnitOptions{Name: "gosec", Cases: ResultCases}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJUnit(&buf, readTestLog(t, "sample.sarif"), tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
+
+func TestJUnitCounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          *JUnitOptions
+		tests         int
+		failures      int
+		errors        int
+		skipped       int
+		invocationErr bool
+	}{
+		{"rules failing on errors and warnings", &JUnitOptions{}, 3, 2, 0, 0, false},
+		{"rules failing on errors", &JUnitOptions{FailureLevels: []string{"error"}}, 3, 1, 0, 0, false},
+		{"results", &JUnitOptions{Cases: ResultCases}, 3, 2, 0, 1, false},
+		{"results failing on notes", &JUnitOptions{Cases: ResultCases, FailureLevels: []string{"note"}}, 3, 0, 0, 1, false},
+		{"failed invocation", &JUnitOptions{Cases: ResultCases}, 4, 2, 1, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := readTestLog(t, "sample.sarif")
+			if tt.invocationErr {
+				inv := log.Runs[0].Invocations[0]
+				inv.ExecutionSuccessful = false
+				inv.ExitCode = 2
+			}
+			var buf bytes.Buffer
+			if err := WriteJUnit(&buf, log, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			var suites junitSuites
+			if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+				t.Fatal(err)
+			}
+			got := [4]int{suites.Tests, suites.Failures, suites.Errors, suites.Skipped}
+			want := [4]int{tt.tests, tt.failures, tt.errors, tt.skipped}
+			if got != want {
+				t.Errorf("tests, failures, errors, skipped = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestJUnitHierarchicalRuleID(t *testing.T) {
+	log := &SARIF{Runs: []*Run{{
+		Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{{Id: "R1"}}}},
+		Results: []*Result{
+			{RuleId: "R1", Level: "note", Message: &Message{Text: "m"}},
+			{RuleId: "R1/sub", Level: "error", Message: &Message{Text: "m"}},
+		},
+	}}}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, log, nil); err != nil {
+		t.Fatal(err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+	if suites.Tests != 1 || suites.Failures != 1 {
+		t.Errorf("tests, failures = %d, %d, want one failing case for R1", suites.Tests, suites.Failures)
+	}
+}
+
+func TestInvocationFailure(t *testing.T) {
+	tests := []struct {
+		inv  Invocation
+		want string
+	}{
+		{Invocation{ProcessStartFailureMessage: "not found", ExitCode: 1}, "not found"},
+		{Invocation{ExitSignalName: "SIGKILL"}, "terminated by signal SIGKILL"},
+		{Invocation{ExitCodeDescription: "bad config", ExitCode: 3}, "bad config"},
+		{Invocation{ExitCode: 3}, "exit code 3"},
+		{Invocation{}, "execution did not succeed"},
+	}
+	for _, tt := range tests {
+		if got := invocationFailure(&tt.inv); got != tt.want {
+			t.Errorf("invocationFailure(%+v) = %q, want %q", tt.inv, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package sarif
+
+// EffectiveLevel returns the level of result as the spec defines it when the
+// result does not state one: a result whose kind is anything but "fail" has
+// level "none"; otherwise Result.Level wins, followed by the level configured
+// for the result's rule by a configuration override of the invocation that
+// produced the result, by the last of Run.Policies that configures the rule,
+// and by the rule's default configuration. The first of these that applies
+// decides the level: a configuration that sets no level means "warning", as
+// does the absence of any configuration.
+func (run *Run) EffectiveLevel(result *Result) string {
+	if result.Kind != "" && result.Kind != "fail" {
+		return "none"
+	}
+	if result.Level != "" {
+		return result.Level
+	}
+	level := ""
+	for _, config := range run.ruleConfigurations(run.rule(result), run.resultInvocations(result)) {
+		level = config.Level
+	}
+	return withDefault(level, "warning")
+}
+
+// EffectiveEnabled reports whether rule is enabled, taking into account its
+// default configuration, Run.Policies and the rule configuration overrides of
+// all invocations, in increasing order of precedence. Rules are enabled unless
+// configured otherwise. Note that a ReportingConfiguration built in Go, rather
+// than decoded from JSON, must set Enabled explicitly to leave a rule enabled.
+func (run *Run) EffectiveEnabled(rule *ReportingDescriptor) bool {
+	enabled := true
+	for _, config := range run.ruleConfigurations(rule, run.Invocations) {
+		enabled = config.Enabled
+	}
+	return enabled
+}
+
+// resultInvocations returns the invocations whose configuration applies to
+// result: the one named by its provenance, or else all of them. An
+// invocation index of 0 is not trusted, since it is also what an absent
+// index decodes as.
+func (run *Run) resultInvocations(result *Result) []*Invocation {
+	if p := result.Provenance; p != nil && p.InvocationIndex > 0 && p.InvocationIndex < len(run.Invocations) {
+		return run.Invocations[p.InvocationIndex : p.InvocationIndex+1]
+	}
+	return run.Invocations
+}
+
+// ruleConfigurations returns the configurations that apply to rule in
+// increasing order of precedence: the rule's default configuration, those of
+// the policies and the overrides of invocations.
+func (run *Run) ruleConfigurations(rule *ReportingDescriptor, invocations []*Invocation) []*ReportingConfiguration {
+	if rule == nil {
+		return nil
+	}
+	var configs []*ReportingConfiguration
+	if rule.DefaultConfiguration != nil {
+		configs = append(configs, rule.DefaultConfiguration)
+	}
+	for _, policy := range run.Policies {
+		if policy == nil {
+			continue
+		}
+		for _, r := range policy.Rules {
+			if r != nil && r.Id == rule.Id && r.DefaultConfiguration != nil {
+				configs = append(configs, r.DefaultConfiguration)
+			}
+		}
+	}
+	for _, inv := range invocations {
+		if inv == nil {
+			continue
+		}
+		for _, o := range inv.RuleConfigurationOverrides {
+			if o != nil && o.Configuration != nil && run.refersToRule(o.Descriptor, rule) {
+				configs = append(configs, o.Configuration)
+			}
+		}
+	}
+	return configs
+}
+
+// refersToRule reports whether ref identifies rule.
+func (run *Run) refersToRule(ref *ReportingDescriptorReference, rule *ReportingDescriptor) bool {
+	d, _, err := run.ResolveDescriptor(ref, RuleDescriptor)
+	return err == nil && d == rule
+}
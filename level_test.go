@@ -0,0 +1,146 @@
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEffectiveLevel(t *testing.T) {
+	rule := &ReportingDescriptor{Id: "R1", DefaultConfiguration: &ReportingConfiguration{Level: "note"}}
+	override := func(level string) *Invocation {
+		return &Invocation{RuleConfigurationOverrides: []*ConfigurationOverride{{
+			Descriptor:    &ReportingDescriptorReference{Id: "R1", Index: -1},
+			Configuration: &ReportingConfiguration{Level: level},
+		}}}
+	}
+	policy := &ToolComponent{Name: "policy", Rules: []*ReportingDescriptor{
+		{Id: "R1", DefaultConfiguration: &ReportingConfiguration{Level: "error"}},
+	}}
+	tests := []struct {
+		name   string
+		run    *Run
+		result *Result
+		want   string
+	}{
+		{
+			name:   "nothing configured",
+			run:    &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t"}}},
+			result: &Result{RuleId: "R1", RuleIndex: -1},
+			want:   "warning",
+		},
+		{
+			name:   "rule default",
+			run:    &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}}},
+			result: &Result{RuleId: "R1", RuleIndex: -1},
+			want:   "note",
+		},
+		{
+			name:   "policy over rule default",
+			run:    &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}}, Policies: []*ToolComponent{policy}},
+			result: &Result{RuleId: "R1", RuleIndex: -1},
+			want:   "error",
+		},
+		{
+			name: "invocation override over policy",
+			run: &Run{
+				Tool:        &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}},
+				Policies:    []*ToolComponent{policy},
+				Invocations: []*Invocation{override("none")},
+			},
+			result: &Result{RuleId: "R1", RuleIndex: -1},
+			want:   "none",
+		},
+		{
+			name: "override of another invocation",
+			run: &Run{
+				Tool:        &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}},
+				Invocations: []*Invocation{override("error"), {}},
+			},
+			result: &Result{RuleId: "R1", RuleIndex: -1, Provenance: &ResultProvenance{InvocationIndex: 1}},
+			want:   "note",
+		},
+		{
+			name: "absent invocation index",
+			run: &Run{
+				Tool:        &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}},
+				Invocations: []*Invocation{{}, override("error")},
+			},
+			result: &Result{RuleId: "R1", RuleIndex: -1, Provenance: &ResultProvenance{}},
+			want:   "error",
+		},
+		{
+			name: "override without level",
+			run: &Run{
+				Tool:        &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}},
+				Invocations: []*Invocation{override("")},
+			},
+			result: &Result{RuleId: "R1", RuleIndex: -1},
+			want:   "warning",
+		},
+		{
+			name: "result level over everything",
+			run: &Run{
+				Tool:        &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}},
+				Invocations: []*Invocation{override("error")},
+			},
+			result: &Result{RuleId: "R1", RuleIndex: -1, Level: "warning"},
+			want:   "warning",
+		},
+		{
+			name:   "kind other than fail",
+			run:    &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t"}}},
+			result: &Result{Kind: "pass", Level: "error"},
+			want:   "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.run.EffectiveLevel(tt.result); got != tt.want {
+				t.Errorf("EffectiveLevel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults string
+		override string
+		want     bool
+	}{
+		{"no configuration", ``, ``, true},
+		{"absent enabled", `{"level": "error"}`, ``, true},
+		{"disabled by default", `{"enabled": false}`, ``, false},
+		{"enabled by override", `{"enabled": false}`, `{"enabled": true}`, true},
+		{"override without enabled", `{"enabled": false}`, `{"level": "note"}`, true},
+		{"disabled by override", `{"enabled": true}`, `{"enabled": false}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &ReportingDescriptor{Id: "R1"}
+			if tt.defaults != "" {
+				rule.DefaultConfiguration = decodeConfiguration(t, tt.defaults)
+			}
+			run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{rule}}}}
+			if tt.override != "" {
+				run.Invocations = []*Invocation{{RuleConfigurationOverrides: []*ConfigurationOverride{{
+					Descriptor:    &ReportingDescriptorReference{Id: "R1", Index: -1},
+					Configuration: decodeConfiguration(t, tt.override),
+				}}}}
+			}
+			if got := run.EffectiveEnabled(rule); got != tt.want {
+				t.Errorf("EffectiveEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func decodeConfiguration(t *testing.T, s string) *ReportingConfiguration {
+	t.Helper()
+	var c ReportingConfiguration
+	if err := json.Unmarshal([]byte(s), &c); err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
@@ -0,0 +1,392 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrTranslationNotFound is returned by Localize when the run has no usable
+// translation for the requested language.
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// Localize applies the translations of run for language, such as "de" or
+// "fr-CA", to the tool components they translate: the driver, the extensions
+// and the taxonomies. A translation applies to the component named by its
+// AssociatedComponent; one whose language matches exactly is preferred over
+// one that only shares the primary language, and among those the one with
+// the highest LocalizedDataSemanticVersion wins. Translations older than the
+// component's MinimumRequiredLocalizedDataSemanticVersion are ignored.
+//
+// The localizable strings of the translation, i.e. the descriptions, help
+// and message strings of the component and of its rules, notifications and
+// taxa, replace those of the component. Rules and other descriptors are
+// matched by guid or, failing that, by id. Messages that refer to a message
+// string that was translated take the translation as their text, so that
+// FormatMessage renders them in the new language, unless the translation
+// cannot be formatted with the message's arguments, in which case they keep
+// their original text. Run.Language is set to language.
+//
+// Localize returns an error wrapping ErrTranslationNotFound if no component
+// could be localized.
+func (run *Run) Localize(language string) error {
+	var components []*ToolComponent
+	if run.Tool != nil {
+		components = append(append(components, run.Tool.Driver), run.Tool.Extensions...)
+	}
+	components = append(components, run.Taxonomies...)
+
+	translated := make(map[*MultiformatMessageString]bool)
+	localized := 0
+	var rejected []string
+	for _, tc := range components {
+		if tc == nil {
+			continue
+		}
+		t, tooOld := run.translationFor(tc, language)
+		rejected = append(rejected, tooOld...)
+		if t == nil {
+			continue
+		}
+		overlayComponent(tc, t, translated)
+		localized++
+	}
+	if localized == 0 {
+		if len(rejected) > 0 {
+			return fmt.Errorf("%w: %q, translations %s are older than required", ErrTranslationNotFound, language, strings.Join(rejected, ", "))
+		}
+		return fmt.Errorf("%w: %q", ErrTranslationNotFound, language)
+	}
+
+	relocalize := func(msg *Message, lookup func(id string) *MultiformatMessageString) {
+		if msg == nil || msg.Id == "" || msg.Text == "" && msg.Markdown == "" {
+			// a message without literal text is looked up anyway
+			return
+		}
+		if s := lookup(msg.Id); translated[s] {
+			relocalizeMessage(msg, s)
+		}
+	}
+	for _, result := range run.Results {
+		if result == nil {
+			continue
+		}
+		rule, tc, _ := run.RuleFor(result)
+		relocalize(result.Message, func(id string) *MultiformatMessageString {
+			return run.lookupMessageString(id, rule, tc)
+		})
+	}
+	for _, inv := range run.Invocations {
+		if inv == nil {
+			continue
+		}
+		for _, n := range append(append([]*Notification{}, inv.ToolConfigurationNotifications...), inv.ToolExecutionNotifications...) {
+			if n == nil {
+				continue
+			}
+			var descriptor *ReportingDescriptor
+			var tc *ToolComponent
+			if n.Descriptor != nil {
+				descriptor, tc, _ = run.ResolveDescriptor(n.Descriptor, NotificationDescriptor)
+			}
+			relocalize(n.Message, func(id string) *MultiformatMessageString {
+				return run.lookupMessageString(id, descriptor, tc)
+			})
+		}
+	}
+	run.Language = language
+	return nil
+}
+
+// relocalizeMessage replaces the literal text of msg with the translated
+// message string s that its id refers to, provided that s can be formatted
+// with the arguments of msg. The Markdown form is dropped if s has none that
+// can be, since it would still be in the original language.
+func relocalizeMessage(msg *Message, s *MultiformatMessageString) {
+	text, ok := localizedText(s.Text, msg.Arguments)
+	if !ok {
+		return
+	}
+	markdown, ok := localizedText(s.Markdown, msg.Arguments)
+	if !ok {
+		markdown = ""
+	}
+	msg.Text, msg.Markdown = text, markdown
+}
+
+// localizedText returns the literal message text that FormatMessage renders
+// as template would be: the template itself for a message with arguments, and
+// the template with its escapes resolved for one without, since literal text
+// without arguments is not treated as a template. It reports false if
+// template is empty or has a placeholder that args lack.
+func localizedText(template string, args []string) (string, bool) {
+	if template == "" {
+		return "", false
+	}
+	formatted, err := substitute(template, args)
+	if err != nil {
+		return "", false
+	}
+	if len(args) == 0 {
+		return formatted, true
+	}
+	return template, true
+}
+
+// translationFor returns the translation of tc into language that Localize
+// applies, and the names of the translations that were ignored because their
+// version is too old.
+func (run *Run) translationFor(tc *ToolComponent, language string) (*ToolComponent, []string) {
+	var best *ToolComponent
+	bestExact := false
+	var rejected []string
+	for _, t := range run.Translations {
+		if t == nil || !translates(t, tc) {
+			continue
+		}
+		exact, ok := matchLanguage(language, t.Language)
+		if !ok {
+			continue
+		}
+		if min := tc.MinimumRequiredLocalizedDataSemanticVersion; min != "" {
+			if c, err := compareSemver(t.LocalizedDataSemanticVersion, min); err != nil || c < 0 {
+				rejected = append(rejected, fmt.Sprintf("%q", t.Name))
+				continue
+			}
+		}
+		if best == nil || exact && !bestExact {
+			best, bestExact = t, exact
+			continue
+		}
+		if exact != bestExact {
+			continue
+		}
+		if c, err := compareSemver(t.LocalizedDataSemanticVersion, best.LocalizedDataSemanticVersion); err == nil && c > 0 {
+			best = t
+		}
+	}
+	return best, rejected
+}
+
+// translates reports whether the translation t is associated with tc.
+func translates(t, tc *ToolComponent) bool {
+	ref := t.AssociatedComponent
+	if ref == nil {
+		return false
+	}
+	if ref.Guid != "" {
+		return strings.EqualFold(ref.Guid, tc.Guid)
+	}
+	return ref.Name != "" && ref.Name == tc.Name
+}
+
+// matchLanguage reports whether the language tag have is acceptable for want,
+// and whether it is an exact match rather than one of the primary language
+// only, as "de" is for "de-AT".
+func matchLanguage(want, have string) (exact, ok bool) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", "-"))
+	}
+	want, have = normalize(want), normalize(have)
+	if want == "" || have == "" {
+		return false, false
+	}
+	if want == have {
+		return true, true
+	}
+	primary := func(s string) string {
+		if i := strings.IndexByte(s, '-'); i >= 0 {
+			return s[:i]
+		}
+		return s
+	}
+	return false, primary(want) == primary(have)
+}
+
+// overlayComponent copies the localizable strings of the translation t onto
+// tc, recording the message strings it installs in translated.
+func overlayComponent(tc, t *ToolComponent, translated map[*MultiformatMessageString]bool) {
+	overlayString(&tc.ShortDescription, t.ShortDescription)
+	overlayString(&tc.FullDescription, t.FullDescription)
+	tc.GlobalMessageStrings = overlayStrings(tc.GlobalMessageStrings, t.GlobalMessageStrings, translated)
+	for _, pair := range [][2][]*ReportingDescriptor{
+		{tc.Rules, t.Rules},
+		{tc.Notifications, t.Notifications},
+		{tc.Taxa, t.Taxa},
+	} {
+		for _, td := range pair[1] {
+			if d := matchingDescriptor(pair[0], td); d != nil {
+				overlayString(&d.ShortDescription, td.ShortDescription)
+				overlayString(&d.FullDescription, td.FullDescription)
+				overlayString(&d.Help, td.Help)
+				d.MessageStrings = overlayStrings(d.MessageStrings, td.MessageStrings, translated)
+			}
+		}
+	}
+}
+
+// matchingDescriptor returns the descriptor of ds that the translated
+// descriptor td corresponds to.
+func matchingDescriptor(ds []*ReportingDescriptor, td *ReportingDescriptor) *ReportingDescriptor {
+	if td == nil {
+		return nil
+	}
+	for _, d := range ds {
+		if d != nil && td.Guid != "" && strings.EqualFold(d.Guid, td.Guid) {
+			return d
+		}
+	}
+	for _, d := range ds {
+		if d != nil && td.Id != "" && d.Id == td.Id {
+			return d
+		}
+	}
+	return nil
+}
+
+func overlayString(dst **MultiformatMessageString, src *MultiformatMessageString) {
+	if src != nil {
+		*dst = src.Clone()
+	}
+}
+
+func overlayStrings(dst, src map[string]*MultiformatMessageString, translated map[*MultiformatMessageString]bool) map[string]*MultiformatMessageString {
+	for id, s := range src {
+		if s == nil {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]*MultiformatMessageString)
+		}
+		dst[id] = s.Clone()
+		translated[dst[id]] = true
+	}
+	return dst
+}
+
+// TranslationSkeleton returns a translation of strct into language that holds
+// all of its localizable strings, still untranslated, ready to be handed to
+// translators and added to Run.Translations. The descriptors of the skeleton
+// carry the id and guid of the descriptors they translate. The skeleton's
+// LocalizedDataSemanticVersion is that of the component's own localized data,
+// or failing that its SemanticVersion.
+func (strct *ToolComponent) TranslationSkeleton(language string) *ToolComponent {
+	t := &ToolComponent{
+		Name:                         strct.Name,
+		Language:                     language,
+		LocalizedDataSemanticVersion: withDefault(strct.LocalizedDataSemanticVersion, strct.SemanticVersion),
+		AssociatedComponent:          &ToolComponentReference{Guid: strct.Guid, Name: strct.Name, Index: -1},
+		TranslationMetadata:          &TranslationMetadata{Name: strct.Name + " (" + language + ")"},
+		ShortDescription:             strct.ShortDescription.Clone(),
+		FullDescription:              strct.FullDescription.Clone(),
+		GlobalMessageStrings:         cloneMap(strct.GlobalMessageStrings),
+	}
+	skeleton := func(ds []*ReportingDescriptor) []*ReportingDescriptor {
+		var out []*ReportingDescriptor
+		for _, d := range ds {
+			if d == nil {
+				continue
+			}
+			out = append(out, &ReportingDescriptor{
+				Id:               d.Id,
+				Guid:             d.Guid,
+				ShortDescription: d.ShortDescription.Clone(),
+				FullDescription:  d.FullDescription.Clone(),
+				Help:             d.Help.Clone(),
+				MessageStrings:   cloneMap(d.MessageStrings),
+			})
+		}
+		return out
+	}
+	t.Rules = skeleton(strct.Rules)
+	t.Notifications = skeleton(strct.Notifications)
+	t.Taxa = skeleton(strct.Taxa)
+	return t
+}
+
+// compareSemver compares two Semantic Versioning 2.0 versions, returning -1,
+// 0 or 1. Build metadata is ignored.
+func compareSemver(a, b string) (int, error) {
+	pa, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < 3; i++ {
+		if c := compareInts(pa.core[i], pb.core[i]); c != 0 {
+			return c, nil
+		}
+	}
+	// a version without pre-release identifiers has the higher precedence
+	switch {
+	case len(pa.pre) == 0 && len(pb.pre) == 0:
+		return 0, nil
+	case len(pa.pre) == 0:
+		return 1, nil
+	case len(pb.pre) == 0:
+		return -1, nil
+	}
+	for i := 0; i < len(pa.pre) && i < len(pb.pre); i++ {
+		x, y := pa.pre[i], pb.pre[i]
+		nx, errx := strconv.Atoi(x)
+		ny, erry := strconv.Atoi(y)
+		var c int
+		switch {
+		case errx == nil && erry == nil:
+			c = compareInts(nx, ny)
+		case errx == nil:
+			c = -1
+		case erry == nil:
+			c = 1
+		default:
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	return compareInts(len(pa.pre), len(pb.pre)), nil
+}
+
+type semver struct {
+	core [3]int
+	pre  []string
+}
+
+func parseSemver(s string) (semver, error) {
+	var v semver
+	rest := s
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.pre = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+	}
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid semantic version %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid semantic version %q", s)
+		}
+		v.core[i] = n
+	}
+	return v, nil
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+package sarif
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	translation := func(name, language, version, text string) *ToolComponent {
+		return &ToolComponent{
+			Name:                         name,
+			Language:                     language,
+			LocalizedDataSemanticVersion: version,
+			AssociatedComponent:          &ToolComponentReference{Name: "linter", Index: -1},
+			Rules: []*ReportingDescriptor{{
+				Id:             "R1",
+				MessageStrings: map[string]*MultiformatMessageString{"default": {Text: text}},
+			}},
+		}
+	}
+	tests := []struct {
+		name         string
+		language     string
+		minimum      string
+		translations []*ToolComponent
+		want         string
+		wantErr      error
+	}{
+		{
+			name:         "exact language",
+			language:     "de-AT",
+			translations: []*ToolComponent{translation("de", "de", "1.0.0", "Hallo {0}"), translation("at", "de-AT", "1.0.0", "Servus {0}")},
+			want:         "Servus x",
+		},
+		{
+			name:         "primary language",
+			language:     "de-AT",
+			translations: []*ToolComponent{translation("de", "de", "1.0.0", "Hallo {0}")},
+			want:         "Hallo x",
+		},
+		{
+			name:         "newest version",
+			language:     "de",
+			translations: []*ToolComponent{translation("old", "de", "1.0.0", "Alt {0}"), translation("new", "de", "1.2.0", "Neu {0}")},
+			want:         "Neu x",
+		},
+		{
+			name:         "release over pre-release",
+			language:     "de",
+			translations: []*ToolComponent{translation("rc", "de", "1.0.0-rc.1", "RC {0}"), translation("final", "de", "1.0.0", "Final {0}")},
+			want:         "Final x",
+		},
+		{
+			name:         "too old",
+			language:     "de",
+			minimum:      "2.0.0",
+			translations: []*ToolComponent{translation("de", "de", "1.0.0", "Hallo {0}")},
+			wantErr:      ErrTranslationNotFound,
+		},
+		{
+			name:         "other language",
+			language:     "fr",
+			translations: []*ToolComponent{translation("de", "de", "1.0.0", "Hallo {0}")},
+			wantErr:      ErrTranslationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &Run{
+				Tool: &Tool{Driver: &ToolComponent{
+					Name: "linter",
+					MinimumRequiredLocalizedDataSemanticVersion: tt.minimum,
+					Rules: []*ReportingDescriptor{{
+						Id:             "R1",
+						MessageStrings: map[string]*MultiformatMessageString{"default": {Text: "Hello {0}"}},
+					}},
+				}},
+				Translations: tt.translations,
+				Results: []*Result{{
+					RuleId:    "R1",
+					RuleIndex: 0,
+					Message:   &Message{Id: "default", Text: "Hello x", Arguments: []string{"x"}},
+				}},
+			}
+			err := run.Localize(tt.language)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Localize() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := run.FormatMessage(run.Results[0], false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+			if run.Language != tt.language {
+				t.Errorf("Run.Language = %q, want %q", run.Language, tt.language)
+			}
+		})
+	}
+}
+
+func TestLocalizeFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *Message
+		want    string
+	}{
+		{"translated", &Message{Id: "default", Text: "Hello x", Arguments: []string{"x"}}, "Hallo x"},
+		{"no arguments", &Message{Id: "default", Text: "Hello x"}, "Hello x"},
+		{"too few arguments", &Message{Id: "pair", Text: "x and y", Arguments: []string{"x"}}, "x and y"},
+		{"escapes without arguments", &Message{Id: "literal", Text: "Hello {x}"}, "Hallo {x}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &Run{
+				Tool: &Tool{Driver: &ToolComponent{
+					Name: "linter",
+					Rules: []*ReportingDescriptor{{
+						Id: "R1",
+						MessageStrings: map[string]*MultiformatMessageString{
+							"default": {Text: "Hello {0}"},
+							"pair":    {Text: "{0} and {1}"},
+							"literal": {Text: "Hello {{x}}"},
+						},
+					}},
+				}},
+				Translations: []*ToolComponent{{
+					Name:                "de",
+					Language:            "de",
+					AssociatedComponent: &ToolComponentReference{Name: "linter", Index: -1},
+					Rules: []*ReportingDescriptor{{
+						Id: "R1",
+						MessageStrings: map[string]*MultiformatMessageString{
+							"default": {Text: "Hallo {0}"},
+							"pair":    {Text: "{0} und {1}"},
+							"literal": {Text: "Hallo {{x}}"},
+						},
+					}},
+				}},
+				Results: []*Result{{RuleId: "R1", Message: tt.message}},
+			}
+			if err := run.Localize("de"); err != nil {
+				t.Fatal(err)
+			}
+			got, err := run.FormatMessage(run.Results[0], false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got, err := compareSemver(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("compareSemver() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if _, err := compareSemver("1.0", "1.0.0"); err == nil {
+		t.Error("compareSemver accepted an invalid version")
+	}
+}
+
+func TestTranslationSkeleton(t *testing.T) {
+	tc := &ToolComponent{
+		Name:             "linter",
+		Guid:             "6f0f0b5e-0000-4000-8000-000000000001",
+		SemanticVersion:  "1.4.0",
+		ShortDescription: &MultiformatMessageString{Text: "A linter"},
+		MinimumRequiredLocalizedDataSemanticVersion: "1.0.0",
+		Rules: []*ReportingDescriptor{{
+			Id:             "R1",
+			Help:           &MultiformatMessageString{Text: "help"},
+			MessageStrings: map[string]*MultiformatMessageString{"default": {Text: "Hello {0}"}},
+			Properties:     &PropertyBag{Tags: []string{"style"}},
+		}},
+	}
+	s := tc.TranslationSkeleton("de")
+	if s.Language != "de" || s.AssociatedComponent.Guid != tc.Guid {
+		t.Errorf("skeleton is not associated with the component: %+v", s)
+	}
+	if s.LocalizedDataSemanticVersion != "1.4.0" {
+		t.Errorf("LocalizedDataSemanticVersion = %q, want the component's version", s.LocalizedDataSemanticVersion)
+	}
+	if s.Rules[0].Properties != nil {
+		t.Error("skeleton carries properties that are not localizable")
+	}
+	s.Rules[0].MessageStrings["default"].Text = "Hallo {0}"
+	if tc.Rules[0].MessageStrings["default"].Text != "Hello {0}" {
+		t.Error("editing the skeleton changed the component")
+	}
+}
@@ -0,0 +1,347 @@
+package sarif
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMarkdownLimit is the default size limit of WriteMarkdown, which
+// keeps the report within the 65536 character limit of a GitHub comment.
+const DefaultMarkdownLimit = 65000
+
+// MarkdownOptions configures WriteMarkdown.
+type MarkdownOptions struct {
+	// Title is the heading of the report; "SARIF report" if empty.
+	Title string
+	// NewOnly restricts the report to results whose baseline state is "new".
+	NewOnly bool
+	// MaxBytes limits the size of the report; DefaultMarkdownLimit if zero.
+	// The results of a rule section that does not fit are cut short, or the
+	// section is left out if none fit, and the results not shown are counted
+	// in a final note.
+	MaxBytes int
+	// MaxResultsPerRule limits the number of results listed per rule; all
+	// are listed if zero.
+	MaxResultsPerRule int
+	// MaxMessageLength limits the length of result messages in runes; 300 if
+	// zero.
+	MaxMessageLength int
+}
+
+// WriteMarkdown writes a GitHub-flavored Markdown report of log to w, suitable
+// for a pull request comment or a job summary: a table of results by level,
+// followed by a collapsible section per rule that lists its results. Result
+// locations link to the exact lines in the repository when the run has
+// version control provenance, see Run.Permalink. opts may be nil.
+func WriteMarkdown(w io.Writer, log *SARIF, opts *MarkdownOptions) error {
+	if opts == nil {
+		opts = &MarkdownOptions{}
+	}
+	limit := opts.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMarkdownLimit
+	}
+	if opts.NewOnly {
+		log = Filter(log, NewQuery(BaselineState("new")))
+	}
+	summary := Summarize(log)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", withDefault(opts.Title, "SARIF report"))
+	qualifier := ""
+	if opts.NewOnly {
+		qualifier = "new "
+	}
+	if summary.Results == 0 {
+		fmt.Fprintf(&b, "No %sresults.\n", qualifier)
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	fmt.Fprintf(&b, "**%d %s%s**\n\n", summary.Results, qualifier, plural(summary.Results, "result", "results"))
+	b.WriteString("| Level | Results |\n| --- | ---: |\n")
+	for _, level := range []string{"error", "warning", "note", "none"} {
+		if n := summary.ByLevel[level]; n > 0 {
+			fmt.Fprintf(&b, "| %s %s | %d |\n", levelEmoji[level], level, n)
+		}
+	}
+
+	sections := markdownSections(log, opts)
+	// later[i] is the size of sections i+1 onwards with a single result each,
+	// which a section that does not fit leaves room for
+	later := make([]int, len(sections))
+	for i := len(sections) - 2; i >= 0; i-- {
+		later[i] = later[i+1] + len(sections[i+1].render(1))
+	}
+	omittedRules, omittedResults := 0, 0
+	for i, section := range sections {
+		// leave room for the note about omitted results
+		room := limit - b.Len() - 200
+		n := section.fit(room)
+		if n < len(section.items) && room-later[i] > 0 {
+			n = section.fit(room - later[i])
+		}
+		if n < len(section.items) {
+			omittedRules++
+			omittedResults += len(section.items) - n
+		}
+		if n > 0 {
+			b.WriteString(section.render(n))
+		}
+	}
+	if omittedRules > 0 {
+		fmt.Fprintf(&b, "\n_Not shown, to keep the report within its size limit: %d %s in %d %s._\n",
+			omittedResults, plural(omittedResults, "result", "results"), omittedRules, plural(omittedRules, "rule", "rules"))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var levelEmoji = map[string]string{
+	"error":   ":x:",
+	"warning": ":warning:",
+	"note":    ":information_source:",
+	"none":    ":white_circle:",
+}
+
+// markdownSection is the section of a rule: a heading followed by a list
+// item per result.
+type markdownSection struct {
+	head    string
+	items   []string
+	results int
+}
+
+// render renders the section with its first n items.
+func (s *markdownSection) render(n int) string {
+	var b strings.Builder
+	b.WriteString(s.head)
+	for _, item := range s.items[:n] {
+		b.WriteString(item)
+	}
+	b.WriteString(s.tail(n))
+	return b.String()
+}
+
+// tail returns the end of the section rendered with its first n items.
+func (s *markdownSection) tail(n int) string {
+	end := "\n</details>\n"
+	if more := s.results - n; more > 0 {
+		end = fmt.Sprintf("- _and %d more %s_\n", more, plural(more, "result", "results")) + end
+	}
+	return end
+}
+
+// fit returns the largest number of items with which the section is at most
+// size bytes long.
+func (s *markdownSection) fit(size int) int {
+	used := len(s.head)
+	for _, item := range s.items {
+		used += len(item)
+	}
+	if used+len(s.tail(len(s.items))) <= size {
+		return len(s.items)
+	}
+	n := 0
+	used = len(s.head)
+	for n < len(s.items) && used+len(s.items[n])+len(s.tail(n+1)) <= size {
+		used += len(s.items[n])
+		n++
+	}
+	if used+len(s.tail(n)) > size {
+		return 0
+	}
+	return n
+}
+
+// markdownSections renders a collapsible section per rule of each run of log,
+// in order of first appearance.
+func markdownSections(log *SARIF, opts *MarkdownOptions) []*markdownSection {
+	maxMessage := opts.MaxMessageLength
+	if maxMessage <= 0 {
+		maxMessage = 300
+	}
+	var sections []*markdownSection
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		type group struct {
+			rule    *ReportingDescriptor
+			id      string
+			results []*Result
+		}
+		var groups []*group
+		byID := make(map[string]*group)
+		for _, result := range run.Results {
+			if result == nil {
+				continue
+			}
+			id := run.ruleID(result)
+			g := byID[id]
+			if g == nil {
+				g = &group{rule: run.rule(result), id: id}
+				byID[id] = g
+				groups = append(groups, g)
+			}
+			g.results = append(g.results, result)
+		}
+		for _, g := range groups {
+			var b strings.Builder
+			title := withDefault(g.id, "(no rule)")
+			if g.rule != nil && g.rule.ShortDescription != nil {
+				title += ": " + messageText(g.rule.ShortDescription)
+			}
+			fmt.Fprintf(&b, "\n<details>\n<summary><strong>%s</strong> (%d)</summary>\n\n", escapeMarkdownHTML(title), len(g.results))
+			if g.rule != nil && g.rule.HelpUri != "" {
+				if link, ok := markdownURL(g.rule.HelpUri); ok {
+					fmt.Fprintf(&b, "[Rule documentation](%s)\n\n", link)
+				}
+			}
+			section := &markdownSection{head: b.String(), results: len(g.results)}
+			for i, result := range g.results {
+				if opts.MaxResultsPerRule > 0 && i == opts.MaxResultsPerRule {
+					break
+				}
+				section.items = append(section.items, "- "+markdownResult(run, result, maxMessage)+"\n")
+			}
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// markdownResult renders a result as a single list item.
+func markdownResult(run *Run, result *Result, maxMessage int) string {
+	level := run.EffectiveLevel(result)
+	var b strings.Builder
+	b.WriteString(levelEmoji[level] + " ")
+	if pl := primaryLocation(result); pl != nil {
+		where := run.displayURI(pl.ArtifactLocation)
+		if pl.Region != nil && pl.Region.StartLine > 0 {
+			where += ":" + strconv.Itoa(pl.Region.StartLine)
+		}
+		if link, ok := markdownURL(run.Permalink(pl)); ok {
+			fmt.Fprintf(&b, "[`%s`](%s)", strings.ReplaceAll(where, "`", "'"), link)
+		} else {
+			fmt.Fprintf(&b, "`%s`", strings.ReplaceAll(where, "`", "'"))
+		}
+		b.WriteString(": ")
+	}
+	msg, err := run.FormatMessage(result, false)
+	if err != nil {
+		msg = "(" + err.Error() + ")"
+	}
+	msg = strings.Join(strings.Fields(msg), " ")
+	if utf8.RuneCountInString(msg) > maxMessage {
+		msg = string([]rune(msg)[:maxMessage-1]) + "…"
+	}
+	b.WriteString(escapeMarkdownCell(msg))
+	return b.String()
+}
+
+// markdownHTMLEscaper replaces the HTML and Markdown metacharacters by
+// character references, which render as the characters themselves both in
+// HTML blocks and in Markdown text.
+var markdownHTMLEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;",
+	`\`, "&#92;", "`", "&#96;", "*", "&#42;", "_", "&#95;", "~", "&#126;",
+	"[", "&#91;", "]", "&#93;", "|", "&#124;", "!", "&#33;",
+)
+
+// escapeMarkdownHTML escapes s for use as text inside an HTML element of a
+// Markdown document.
+func escapeMarkdownHTML(s string) string {
+	return markdownHTMLEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// markdownURL returns s, which must be an absolute http or https URL, with
+// the characters that would end a Markdown link destination escaped.
+func markdownURL(s string) (string, bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", false
+	}
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E", "\\", "%5C", "`", "%60").Replace(u.String()), true
+}
+
+// Permalink returns a link to the lines of loc at the commit recorded as the
+// RevisionId of the run's VersionControlProvenance, in the form used by
+// GitHub:
+//
+//	https://github.com/org/repo/blob/<revision>/path/to/file.go#L10-L12
+//
+// The artifact is located in the repository through the MappedTo location of
+// the provenance, or taken as relative to the repository root if it has
+// none. Permalink returns "" if no provenance with a revision id covers loc:
+// a branch or tag may have moved on since the analysis, so that the lines
+// they link to are not those of the result.
+func (run *Run) Permalink(loc *PhysicalLocation) string {
+	if loc == nil || loc.ArtifactLocation == nil {
+		return ""
+	}
+	for _, vcs := range run.VersionControlProvenance {
+		if vcs == nil || vcs.RepositoryUri == "" {
+			continue
+		}
+		revision := vcs.RevisionId
+		if revision == "" {
+			continue
+		}
+		rel, ok := run.repositoryPath(vcs, loc.ArtifactLocation)
+		if !ok {
+			continue
+		}
+		link := strings.TrimSuffix(strings.TrimSuffix(vcs.RepositoryUri, "/"), ".git") + "/blob/" + url.PathEscape(revision) + "/" + rel
+		if r := loc.Region; r != nil && r.StartLine > 0 {
+			link += "#L" + strconv.Itoa(r.StartLine)
+			if r.EndLine > r.StartLine {
+				link += "-L" + strconv.Itoa(r.EndLine)
+			}
+		}
+		return link
+	}
+	return ""
+}
+
+// repositoryPath returns the escaped path of loc relative to the root of the
+// repository of vcs.
+func (run *Run) repositoryPath(vcs *VersionControlDetails, loc *ArtifactLocation) (string, bool) {
+	if loc.Uri == "" {
+		if a := run.artifactFor(loc); a != nil && a.Location != nil && a.Location != loc {
+			loc = a.Location
+		}
+	}
+	u, err := url.Parse(loc.Uri)
+	if err != nil {
+		return "", false
+	}
+	root := vcs.MappedTo
+	if root == nil {
+		if u.IsAbs() || loc.UriBaseId != "" || strings.HasPrefix(u.Path, "/") {
+			return "", false
+		}
+		return strings.TrimPrefix(u.EscapedPath(), "./"), true
+	}
+	if root.Uri == "" && root.UriBaseId != "" && root.UriBaseId == loc.UriBaseId && !u.IsAbs() {
+		return strings.TrimPrefix(u.EscapedPath(), "./"), true
+	}
+	var base *url.URL
+	if root.Uri == "" && root.UriBaseId != "" {
+		base, err = run.resolveBaseID(root.UriBaseId, nil)
+	} else {
+		base, err = run.ResolveURI(root)
+	}
+	if err != nil || !base.IsAbs() {
+		return "", false
+	}
+	target, err := run.ResolveURI(loc)
+	if err != nil || !target.IsAbs() {
+		return "", false
+	}
+	rel, ok := relativeTo(base, target)
+	return strings.TrimPrefix(rel, "./"), ok
+}
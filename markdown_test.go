@@ -0,0 +1,177 @@
+package sarif
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, readTestLog(t, "sample.sarif"), nil); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "report.md", buf.Bytes())
+}
+
+// sizedLog returns a log with a rule per entry of counts, each with that many
+// results.
+func sizedLog(counts ...int) *SARIF {
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t"}}}
+	for i, n := range counts {
+		for j := 0; j < n; j++ {
+			run.Results = append(run.Results, &Result{
+				RuleId:  fmt.Sprintf("R%d", i),
+				Message: &Message{Text: strings.Repeat("x", 80)},
+			})
+		}
+	}
+	return &SARIF{Runs: []*Run{run}}
+}
+
+func TestWriteMarkdownLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      *SARIF
+		maxBytes int
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "everything fits",
+			log:      sizedLog(3, 1),
+			maxBytes: 4000,
+			contains: []string{"<strong>R0</strong> (3)", "<strong>R1</strong> (1)"},
+			excludes: []string{"Not shown"},
+		},
+		{
+			name:     "large section is cut short and later ones are kept",
+			log:      sizedLog(50, 1),
+			maxBytes: 2000,
+			contains: []string{"<strong>R0</strong> (50)", "more results_", "<strong>R1</strong> (1)", "Not shown, to keep the report within its size limit:"},
+		},
+		{
+			name:     "section that does not fit at all",
+			log:      sizedLog(1, 1),
+			maxBytes: 350,
+			contains: []string{"2 results in 2 rules._"},
+			excludes: []string{"<details>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteMarkdown(&buf, tt.log, &MarkdownOptions{MaxBytes: tt.maxBytes}); err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			if len(got) > tt.maxBytes {
+				t.Errorf("report is %d bytes long, want at most %d", len(got), tt.maxBytes)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(got, s) {
+					t.Errorf("report does not contain %q:\n%s", s, got)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(got, s) {
+					t.Errorf("report contains %q:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownSectionFit(t *testing.T) {
+	s := &markdownSection{head: "head\n", items: []string{"- a\n", "- b\n", "- c\n"}, results: 3}
+	full := len(s.render(len(s.items)))
+	if got := s.fit(full); got != len(s.items) {
+		t.Errorf("fit(%d) = %d, want %d", full, got, len(s.items))
+	}
+	for size := 0; size < 2*full; size++ {
+		if n := s.fit(size); n > 0 && len(s.render(n)) > size {
+			t.Errorf("fit(%d) = %d, which renders %d bytes", size, n, len(s.render(n)))
+		}
+	}
+}
+
+func TestEscapeMarkdownHTML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"G101: Hardcoded credentials", "G101: Hardcoded credentials"},
+		{"a <b> & c", "a &lt;b&gt; &amp; c"},
+		{"*x* _y_ `z` [l](u) ~s~ a|b !i", "&#42;x&#42; &#95;y&#95; &#96;z&#96; &#91;l&#93;(u) &#126;s&#126; a&#124;b &#33;i"},
+		{"two\nlines", "two lines"},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdownHTML(tt.in); got != tt.want {
+			t.Errorf("escapeMarkdownHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"https://example.com/docs/g101.html", "https://example.com/docs/g101.html", true},
+		{"https://example.com/a_(b)", "https://example.com/a_%28b%29", true},
+		{"https://example.com/a b", "https://example.com/a%20b", true},
+		{"https://example.com/x)[y](javascript:alert(1)", "https://example.com/x%29[y]%28javascript:alert%281%29", true},
+		{"javascript:alert(1)", "", false},
+		{"docs/g101.html", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := markdownURL(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("markdownURL(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPermalink(t *testing.T) {
+	loc := &PhysicalLocation{
+		ArtifactLocation: &ArtifactLocation{Uri: "pkg/x.go", Index: -1},
+		Region:           &Region{StartLine: 10, EndLine: 12},
+	}
+	tests := []struct {
+		name string
+		vcs  VersionControlDetails
+		want string
+	}{
+		{
+			name: "commit",
+			vcs:  VersionControlDetails{RepositoryUri: "https://github.com/org/repo.git", RevisionId: "abc123"},
+			want: "https://github.com/org/repo/blob/abc123/pkg/x.go#L10-L12",
+		},
+		{
+			name: "commit and branch",
+			vcs:  VersionControlDetails{RepositoryUri: "https://github.com/org/repo", RevisionId: "abc123", Branch: "main"},
+			want: "https://github.com/org/repo/blob/abc123/pkg/x.go#L10-L12",
+		},
+		{
+			name: "branch only",
+			vcs:  VersionControlDetails{RepositoryUri: "https://github.com/org/repo", Branch: "main"},
+			want: "",
+		},
+		{
+			name: "tag only",
+			vcs:  VersionControlDetails{RepositoryUri: "https://github.com/org/repo", RevisionTag: "v1.0.0"},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcs := tt.vcs
+			run := &Run{VersionControlProvenance: []*VersionControlDetails{&vcs}}
+			if got := run.Permalink(loc); got != tt.want {
+				t.Errorf("Permalink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
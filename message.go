@@ -0,0 +1,272 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrMessageNotFound is returned when a message refers by id to a message
+// string that is not defined.
+var ErrMessageNotFound = errors.New("message string not found")
+
+// FormatMessage returns the message of result ready for display. A message
+// with literal text is used as is; a message that only has an Id is looked up
+// in the MessageStrings of the result's rule and then in the
+// GlobalMessageStrings of the tool component that defines the rule and of the
+// driver. The Markdown form is returned if preferMarkdown is set and one
+// exists, and the plain text form otherwise.
+//
+// Placeholders such as {0} are replaced with the message Arguments. A doubled
+// brace stands for a literal one, as the spec requires, and apostrophes quote
+// as in ICU message formats: two apostrophes stand for one, and an apostrophe
+// before a brace quotes the text up to the next apostrophe, so that
+// "'{0}' is {0}" with the argument "x" formats as "{0} is x". Other
+// apostrophes are literal. Literal text without arguments is not treated as a
+// template. Embedded links whose target is an integer, as in
+// "[the source](1)", refer to the related location with that id: in Markdown
+// the target is replaced with the location's URI, in plain text only the link
+// text is kept.
+func (run *Run) FormatMessage(result *Result, preferMarkdown bool) (string, error) {
+	msg := result.Message
+	if msg == nil {
+		return "", errors.New("result has no message")
+	}
+	markdown, text := msg.Markdown, msg.Text
+	templated := len(msg.Arguments) > 0
+	if text == "" && markdown == "" {
+		if msg.Id == "" {
+			return "", errors.New("message has neither text nor id")
+		}
+		rule, tc, _ := run.RuleFor(result)
+		s := run.lookupMessageString(msg.Id, rule, tc)
+		if s == nil {
+			return "", fmt.Errorf("%w: %q", ErrMessageNotFound, msg.Id)
+		}
+		markdown, text = s.Markdown, s.Text
+		templated = true
+	}
+	useMarkdown := preferMarkdown && markdown != ""
+	template := text
+	if useMarkdown {
+		template = markdown
+	}
+	if templated {
+		var err error
+		if template, err = substitute(template, msg.Arguments); err != nil {
+			return "", err
+		}
+	}
+	return run.resolveLinks(template, result, useMarkdown), nil
+}
+
+// lookupMessageString finds the message string id in rule and in the global
+// message strings of tc and the driver, in that order.
+func (run *Run) lookupMessageString(id string, rule *ReportingDescriptor, tc *ToolComponent) *MultiformatMessageString {
+	if rule != nil {
+		if s := rule.MessageStrings[id]; s != nil {
+			return s
+		}
+	}
+	if tc != nil {
+		if s := tc.GlobalMessageStrings[id]; s != nil {
+			return s
+		}
+	}
+	if run.Tool != nil && run.Tool.Driver != nil {
+		if s := run.Tool.Driver.GlobalMessageStrings[id]; s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// substitute replaces the placeholders {n} in template with args[n] and
+// resolves the escapes of scanTemplate.
+func substitute(template string, args []string) (string, error) {
+	var b strings.Builder
+	err := scanTemplate(template, func(literal string, placeholder int) error {
+		if placeholder < 0 {
+			b.WriteString(literal)
+			return nil
+		}
+		if placeholder >= len(args) {
+			return fmt.Errorf("placeholder {%d} has no argument, the message has %d", placeholder, len(args))
+		}
+		b.WriteString(args[placeholder])
+		return nil
+	})
+	return b.String(), err
+}
+
+// scanTemplate splits a message string into literal text and placeholders,
+// calling emit with placeholder set to -1 for literal text and to the
+// placeholder's number otherwise. Doubled braces and apostrophes are emitted
+// as single ones, and text quoted by an apostrophe before a brace is emitted
+// as is; an unterminated quote extends to the end of the template.
+func scanTemplate(template string, emit func(literal string, placeholder int) error) error {
+	start := 0
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c == '\'' {
+			if i+1 >= len(template) || !strings.ContainsRune("'{}", rune(template[i+1])) {
+				continue
+			}
+			if err := emit(template[start:i], -1); err != nil {
+				return err
+			}
+			if template[i+1] == '\'' {
+				// an escaped apostrophe
+				if err := emit("'", -1); err != nil {
+					return err
+				}
+				i++
+				start = i + 1
+				continue
+			}
+			quoted, n := unquoteTemplate(template[i+1:])
+			if err := emit(quoted, -1); err != nil {
+				return err
+			}
+			i += n
+			start = i + 1
+			continue
+		}
+		if c != '{' && c != '}' {
+			continue
+		}
+		if err := emit(template[start:i], -1); err != nil {
+			return err
+		}
+		if i+1 < len(template) && template[i+1] == c {
+			// an escaped brace
+			if err := emit(string(c), -1); err != nil {
+				return err
+			}
+			i++
+			start = i + 1
+			continue
+		}
+		if c == '}' {
+			return fmt.Errorf("unmatched } at offset %d", i)
+		}
+		end := strings.IndexByte(template[i:], '}')
+		if end < 0 {
+			return fmt.Errorf("unterminated placeholder at offset %d", i)
+		}
+		n, err := strconv.Atoi(template[i+1 : i+end])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid placeholder %s at offset %d", template[i:i+end+1], i)
+		}
+		if err := emit("", n); err != nil {
+			return err
+		}
+		i += end
+		start = i + 1
+	}
+	return emit(template[start:], -1)
+}
+
+// unquoteTemplate returns the text quoted at the start of s, up to the next
+// apostrophe that is not doubled, and the number of bytes it spans including
+// the closing apostrophe.
+func unquoteTemplate(s string) (string, int) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		return b.String(), i + 1
+	}
+	return b.String(), len(s)
+}
+
+// resolveLinks rewrites the embedded links of a formatted message whose
+// targets refer to related locations of result by id.
+func (run *Run) resolveLinks(s string, result *Result, markdown bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && (s[i+1] == '[' || s[i+1] == ']') {
+			if markdown {
+				b.WriteByte(c)
+			}
+			b.WriteByte(s[i+1])
+			i++
+			continue
+		}
+		if c != '[' {
+			b.WriteByte(c)
+			continue
+		}
+		text, target, n, ok := parseLink(s[i:])
+		if !ok {
+			b.WriteByte(c)
+			continue
+		}
+		id, err := strconv.Atoi(target)
+		if err != nil {
+			// not a reference to a location, leave it alone
+			b.WriteString(s[i : i+n])
+		} else if !markdown {
+			b.WriteString(run.resolveLinks(text, result, false))
+		} else if uri := run.relatedLocationURI(result, id); uri != "" {
+			fmt.Fprintf(&b, "[%s](%s)", text, uri)
+		} else {
+			b.WriteString(text)
+		}
+		i += n - 1
+	}
+	return b.String()
+}
+
+// parseLink parses a link "[text](target)" at the start of s and returns its
+// parts and length.
+func parseLink(s string) (text, target string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			return s[1:i], s[i+2 : i+2+end], i + 3 + end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// relatedLocationURI returns the URI of the related location of result with
+// the given id.
+func (run *Run) relatedLocationURI(result *Result, id int) string {
+	for _, loc := range result.RelatedLocations {
+		if loc == nil || loc.Id != id || loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation == nil {
+			continue
+		}
+		al := loc.PhysicalLocation.ArtifactLocation
+		if u, err := run.ResolveURI(al); err == nil {
+			return u.String()
+		}
+		return al.Uri
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package sarif
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		template string
+		args     []string
+		want     string
+		wantErr  bool
+	}{
+		{"plain", nil, "plain", false},
+		{"{0} and {1}", []string{"a", "b"}, "a and b", false},
+		{"{1}{0}{1}", []string{"a", "b"}, "bab", false},
+		{"{{0}} is {0}", []string{"x"}, "{0} is x", false},
+		{"'{0}' is {0}", []string{"x"}, "{0} is x", false},
+		{"it''s {0}", []string{"x"}, "it's x", false},
+		{"don't {0}", []string{"x"}, "don't x", false},
+		{"'{0} ''quoted'' {1}' {0}", []string{"x"}, "{0} 'quoted' {1} x", false},
+		{"'{unterminated", nil, "{unterminated", false},
+		{"trailing '", nil, "trailing '", false},
+		{"{0}", nil, "", true},
+		{"{", nil, "", true},
+		{"}", nil, "", true},
+		{"{x}", []string{"a"}, "", true},
+		{"{-1}", []string{"a"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			got, err := substitute(tt.template, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("substitute() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("substitute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{
+		Name: "t",
+		Rules: []*ReportingDescriptor{{
+			Id: "R1",
+			MessageStrings: map[string]*MultiformatMessageString{
+				"default": {Text: "Variable {0} is unused.", Markdown: "Variable `{0}` is unused."},
+				"shared":  {Text: "from the rule"},
+			},
+		}},
+		GlobalMessageStrings: map[string]*MultiformatMessageString{
+			"shared": {Text: "from the driver"},
+			"global": {Text: "global {0}"},
+		},
+	}}}
+	related := []*Location{{Id: 1, PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{Uri: "src/a.go", Index: -1}}}}
+	tests := []struct {
+		name     string
+		msg      *Message
+		markdown bool
+		want     string
+		wantErr  error
+	}{
+		{"literal text", &Message{Text: "it's {0}"}, false, "it's {0}", nil},
+		{"literal text with arguments", &Message{Text: "{0} found", Arguments: []string{"x"}}, false, "x found", nil},
+		{"rule message string", &Message{Id: "default", Arguments: []string{"x"}}, false, "Variable x is unused.", nil},
+		{"markdown form", &Message{Id: "default", Arguments: []string{"x"}}, true, "Variable `x` is unused.", nil},
+		{"rule before driver", &Message{Id: "shared"}, false, "from the rule", nil},
+		{"driver global", &Message{Id: "global", Arguments: []string{"g"}}, false, "global g", nil},
+		{"unknown id", &Message{Id: "nope"}, false, "", ErrMessageNotFound},
+		{"link in text", &Message{Text: "see [the source](1)"}, false, "see the source", nil},
+		{"link in markdown", &Message{Text: "t", Markdown: "see [the source](1)"}, true, "see [the source](src/a.go)", nil},
+		{"unknown link id", &Message{Text: "t", Markdown: "see [x](2)"}, true, "see x", nil},
+		{"other link", &Message{Text: "see [docs](https://example.com)"}, false, "see [docs](https://example.com)", nil},
+		{"escaped brackets", &Message{Text: `\[not a link\](1)`}, false, "[not a link](1)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &Result{RuleId: "R1", RuleIndex: 0, Message: tt.msg, RelatedLocations: related}
+			got, err := run.FormatMessage(result, tt.markdown)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FormatMessage() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package sarif
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Predicate reports whether result, which belongs to run, satisfies a
+// condition.
+type Predicate func(run *Run, result *Result) bool
+
+// Query selects results from a SARIF log. A result matches a query if it
+// satisfies all of the query's predicates; the zero Query matches every
+// result.
+type Query struct {
+	predicates []Predicate
+}
+
+// NewQuery returns a query that matches the results satisfying all of preds.
+func NewQuery(preds ...Predicate) *Query {
+	return &Query{predicates: preds}
+}
+
+// Where adds preds to q and returns q to allow chaining.
+func (q *Query) Where(preds ...Predicate) *Query {
+	q.predicates = append(q.predicates, preds...)
+	return q
+}
+
+// Match reports whether result, which belongs to run, matches q.
+func (q *Query) Match(run *Run, result *Result) bool {
+	for _, p := range q.predicates {
+		if !p(run, result) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match is a result selected by a Query, together with its position in the
+// log.
+type Match struct {
+	Run         *Run
+	RunIndex    int
+	Result      *Result
+	ResultIndex int
+}
+
+// Results returns the results of all runs of log that match q, in log order.
+func (q *Query) Results(log *SARIF) []Match {
+	var matches []Match
+	for i, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		for j, result := range run.Results {
+			if result != nil && q.Match(run, result) {
+				matches = append(matches, Match{Run: run, RunIndex: i, Result: result, ResultIndex: j})
+			}
+		}
+	}
+	return matches
+}
+
+// And returns a predicate satisfied when all of preds are.
+func And(preds ...Predicate) Predicate {
+	return func(run *Run, result *Result) bool {
+		for _, p := range preds {
+			if !p(run, result) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate satisfied when any of preds is.
+func Or(preds ...Predicate) Predicate {
+	return func(run *Run, result *Result) bool {
+		for _, p := range preds {
+			if p(run, result) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate satisfied when pred is not.
+func Not(pred Predicate) Predicate {
+	return func(run *Run, result *Result) bool {
+		return !pred(run, result)
+	}
+}
+
+// RuleID matches results whose rule id is one of ids. A hierarchical rule id
+// such as "CA2000/sub" also matches its parent "CA2000".
+func RuleID(ids ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		ruleID := run.ruleID(result)
+		for _, id := range ids {
+			if ruleID == id || strings.HasPrefix(ruleID, id+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Level matches results whose effective level, as computed by
+// Run.EffectiveLevel, is one of levels.
+func Level(levels ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		return contains(levels, run.EffectiveLevel(result))
+	}
+}
+
+// Kind matches results whose kind is one of kinds, where an absent kind means
+// "fail".
+func Kind(kinds ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		return contains(kinds, withDefault(result.Kind, "fail"))
+	}
+}
+
+// BaselineState matches results whose baseline state is one of states.
+func BaselineState(states ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		return contains(states, result.BaselineState)
+	}
+}
+
+// Suppressed matches results that are suppressed, as defined by
+// Result.IsSuppressed.
+func Suppressed() Predicate {
+	return func(run *Run, result *Result) bool {
+		return result.IsSuppressed()
+	}
+}
+
+// URIGlob matches results with a location whose artifact URI matches one of
+// patterns, e.g. "pkg/api/**" or "**/*_test.go". The URI is matched as written
+// in the log, after percent-decoding and with the scheme of file URIs
+// stripped.
+func URIGlob(patterns ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		for _, uri := range run.resultURIs(result) {
+			for _, p := range patterns {
+				if matchGlob(p, uri) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// Tag matches results tagged with any of tags, either in the result's own
+// property bag or in that of its rule.
+func Tag(tags ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		if result.Properties != nil && containsAny(result.Properties.Tags, tags) {
+			return true
+		}
+		rule := run.rule(result)
+		return rule != nil && rule.Properties != nil && containsAny(rule.Properties.Tags, tags)
+	}
+}
+
+// Taxon matches results that reference one of the taxa with the given ids,
+// such as "CWE-79".
+func Taxon(ids ...string) Predicate {
+	return func(run *Run, result *Result) bool {
+		for _, t := range result.Taxa {
+			if t != nil && contains(ids, t.Id) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RankRange matches results whose rank lies within [min, max].
+func RankRange(min, max float64) Predicate {
+	return func(run *Run, result *Result) bool {
+		return result.Rank >= min && result.Rank <= max
+	}
+}
+
+// IsSuppressed reports whether strct is suppressed: it has at least one
+// suppression and none of them is under review or rejected.
+func (strct *Result) IsSuppressed() bool {
+	if len(strct.Suppressions) == 0 {
+		return false
+	}
+	for _, s := range strct.Suppressions {
+		if s != nil && (s.State == "underReview" || s.State == "rejected") {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleID returns the id of the rule that result was produced by.
+func (run *Run) ruleID(result *Result) string {
+	if result.RuleId != "" {
+		return result.RuleId
+	}
+	if result.Rule != nil && result.Rule.Id != "" {
+		return result.Rule.Id
+	}
+	if rule := run.rule(result); rule != nil {
+		return rule.Id
+	}
+	return ""
+}
+
+// rule returns the rule that result was produced by, or nil if it cannot be
+// resolved.
+func (run *Run) rule(result *Result) *ReportingDescriptor {
+	rule, _, _ := run.RuleFor(result)
+	return rule
+}
+
+// resultRuleIndex returns the index of the driver rule that result refers to,
+// or -1. The index of a result is only trusted when its rule id confirms it,
+// since an absent index decodes as 0; that of its rule reference is, as the
+// reference exists to name the rule.
+func (run *Run) resultRuleIndex(result *Result) int {
+	if run.Tool == nil || run.Tool.Driver == nil {
+		return -1
+	}
+	if r := result.Rule; r != nil {
+		if r.ToolComponent != nil {
+			return -1
+		}
+		return run.driverRuleIndex(&ReportingDescriptorReference{Id: withDefault(r.Id, result.RuleId), Guid: r.Guid, Index: r.Index}, true)
+	}
+	return run.driverRuleIndex(&ReportingDescriptorReference{Id: result.RuleId, Index: result.RuleIndex}, false)
+}
+
+// driverRuleIndex returns the index of the driver rule that ref refers to, or
+// -1. A reference with a guid or an id refers to the rule with that guid or
+// id, or to the closest ancestor of a hierarchical id, and its index is only
+// used when the rule there agrees. The index of a reference with neither is
+// used only if trustIndex is set.
+func (run *Run) driverRuleIndex(ref *ReportingDescriptorReference, trustIndex bool) int {
+	rules := run.Tool.Driver.Rules
+	agrees := func(i int) bool {
+		return i >= 0 && i < len(rules) && rules[i] != nil &&
+			(ref.Id == "" || rules[i].Id == ref.Id) && (ref.Guid == "" || strings.EqualFold(rules[i].Guid, ref.Guid))
+	}
+	if ref.Id == "" && ref.Guid == "" {
+		if trustIndex && agrees(ref.Index) {
+			return ref.Index
+		}
+		return -1
+	}
+	if agrees(ref.Index) {
+		return ref.Index
+	}
+	if ref.Guid != "" {
+		for i, rule := range rules {
+			if rule != nil && strings.EqualFold(rule.Guid, ref.Guid) {
+				return i
+			}
+		}
+	}
+	for id := ref.Id; id != ""; {
+		for i, rule := range rules {
+			if rule != nil && rule.Id == id {
+				return i
+			}
+		}
+		slash := strings.LastIndex(id, "/")
+		if slash < 0 {
+			break
+		}
+		id = id[:slash]
+	}
+	return -1
+}
+
+// resultURIs returns the decoded artifact URIs of the locations of result.
+func (run *Run) resultURIs(result *Result) []string {
+	var uris []string
+	for _, loc := range result.Locations {
+		if loc == nil || loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation == nil {
+			continue
+		}
+		if uri := run.displayURI(loc.PhysicalLocation.ArtifactLocation); uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// displayURI returns the URI of loc as written in the log, percent-decoded and
+// with the scheme of file URIs stripped.
+func (run *Run) displayURI(loc *ArtifactLocation) string {
+	uri := loc.Uri
+	if uri == "" {
+		if a := run.artifactFor(loc); a != nil && a.Location != nil && a.Location != loc {
+			uri = a.Location.Uri
+		}
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	if u.Scheme == "file" || u.Scheme == "" {
+		return u.Path
+	}
+	return u.String()
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list, wanted []string) bool {
+	for _, s := range wanted {
+		if contains(list, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package sarif
+
+import (
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	run := &Run{
+		Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{
+			{Id: "CA2000", Properties: &PropertyBag{Tags: []string{"security"}}},
+		}}},
+		Artifacts: []*Artifact{{Location: &ArtifactLocation{Uri: "file:///src/pkg/api/x%20y.go"}}},
+	}
+	result := &Result{
+		RuleId:        "CA2000/sub",
+		RuleIndex:     0,
+		Level:         "error",
+		Rank:          40,
+		BaselineState: "new",
+		Taxa:          []*ReportingDescriptorReference{{Id: "CWE-79", Index: -1}},
+		Locations: []*Location{{PhysicalLocation: &PhysicalLocation{
+			ArtifactLocation: &ArtifactLocation{Index: 0},
+		}}},
+	}
+	suppressed := func(states ...string) *Result {
+		r := &Result{}
+		for _, s := range states {
+			r.Suppressions = append(r.Suppressions, &Suppression{Kind: "inSource", State: s})
+		}
+		return r
+	}
+	tests := []struct {
+		name   string
+		pred   Predicate
+		result *Result
+		want   bool
+	}{
+		{"rule id", RuleID("CA2000/sub"), result, true},
+		{"parent rule id", RuleID("CA2000"), result, true},
+		{"rule id prefix is not a parent", RuleID("CA20"), result, false},
+		{"level", Level("warning", "error"), result, true},
+		{"other level", Level("note"), result, false},
+		{"default kind", Kind("fail"), result, true},
+		{"baseline state", BaselineState("new"), result, true},
+		{"uri glob through the artifact", URIGlob("/src/**/*.go"), result, true},
+		{"decoded uri", URIGlob("**/x y.go"), result, true},
+		{"uri glob mismatch", URIGlob("**/*_test.go"), result, false},
+		{"rule tag", Tag("security"), result, true},
+		{"missing tag", Tag("style"), result, false},
+		{"taxon", Taxon("CWE-79"), result, true},
+		{"rank in range", RankRange(0, 50), result, true},
+		{"rank out of range", RankRange(50, 100), result, false},
+		{"and", And(Level("error"), Tag("security")), result, true},
+		{"or", Or(Level("note"), Tag("security")), result, true},
+		{"not", Not(Level("error")), result, false},
+		{"unsuppressed", Suppressed(), result, false},
+		{"suppressed", Suppressed(), suppressed("accepted"), true},
+		{"suppression without state", Suppressed(), suppressed(""), true},
+		{"suppression under review", Suppressed(), suppressed("accepted", "underReview"), false},
+		{"rejected suppression", Suppressed(), suppressed("rejected"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(run, tt.result); got != tt.want {
+				t.Errorf("predicate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryResults(t *testing.T) {
+	log := &SARIF{Runs: []*Run{
+		{Results: []*Result{{Level: "error"}, {Level: "note"}}},
+		nil,
+		{Results: []*Result{nil, {Level: "error"}}},
+	}}
+	tests := []struct {
+		name string
+		q    *Query
+		want [][2]int
+	}{
+		{"zero query", &Query{}, [][2]int{{0, 0}, {0, 1}, {2, 1}}},
+		{"where", NewQuery().Where(Level("error")), [][2]int{{0, 0}, {2, 1}}},
+		{"no match", NewQuery(Level("error"), Level("note")), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+			for _, m := range tt.q.Results(log) {
+				got = append(got, [2]int{m.RunIndex, m.ResultIndex})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Results() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Results() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
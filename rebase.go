@@ -0,0 +1,264 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Rebase rewrites every artifact location in log that lies under root into a
+// location relative to the uriBaseId baseID, for example turning
+// /home/runner/work/repo/repo/pkg/x.go into pkg/x.go relative to %SRCROOT%.
+// root may be a local file system path or an absolute URI. Locations that are
+// already relative to another uriBaseId are rebased if that base resolves
+// under root. The mapping from baseID to root is recorded in
+// Run.OriginalUriBaseIds of every run. Rebase returns the number of locations
+// it rewrote.
+func Rebase(log *SARIF, root, baseID string) (int, error) {
+	base, err := parseAbsolute(root)
+	if err != nil {
+		return 0, err
+	}
+	if base.Path != "" && base.Path[len(base.Path)-1] != '/' {
+		base.Path += "/"
+		if base.RawPath != "" {
+			base.RawPath += "/"
+		}
+	}
+	for _, run := range log.Runs {
+		if existing, ok := run.OriginalUriBaseIds[baseID]; ok && existing != nil && existing.Uri != base.String() {
+			return 0, fmt.Errorf("uriBaseId %q is already defined as %q", baseID, existing.Uri)
+		}
+	}
+	rewritten := 0
+	for _, run := range log.Runs {
+		for _, loc := range run.artifactLocations() {
+			target, err := run.absoluteURI(loc)
+			if err != nil {
+				continue
+			}
+			rel, ok := relativeTo(base, target)
+			if !ok || rel == "" {
+				continue
+			}
+			loc.Uri = rel
+			loc.UriBaseId = baseID
+			rewritten++
+		}
+		if run.OriginalUriBaseIds == nil {
+			run.OriginalUriBaseIds = make(map[string]*ArtifactLocation)
+		}
+		run.OriginalUriBaseIds[baseID] = &ArtifactLocation{Uri: base.String()}
+	}
+	return rewritten, nil
+}
+
+// absoluteURI is like ResolveURI but additionally accepts absolute file system
+// paths in place of file URIs, as many tools emit them.
+func (run *Run) absoluteURI(loc *ArtifactLocation) (*url.URL, error) {
+	if loc.Uri == "" {
+		return nil, errors.New("artifact location has no uri")
+	}
+	if loc.UriBaseId == "" {
+		return parseAbsolute(loc.Uri)
+	}
+	return run.ResolveURI(loc)
+}
+
+// artifactLocations collects every artifact location reachable from run,
+// except the definitions in Run.OriginalUriBaseIds.
+func (run *Run) artifactLocations() []*ArtifactLocation {
+	var locs []*ArtifactLocation
+	add := func(l *ArtifactLocation) {
+		if l != nil {
+			locs = append(locs, l)
+		}
+	}
+	var location func(*Location)
+	stack := func(s *Stack) {
+		if s == nil {
+			return
+		}
+		for _, f := range s.Frames {
+			if f != nil {
+				location(f.Location)
+			}
+		}
+	}
+	physical := func(p *PhysicalLocation) {
+		if p != nil {
+			add(p.ArtifactLocation)
+		}
+	}
+	location = func(l *Location) {
+		if l != nil {
+			physical(l.PhysicalLocation)
+		}
+	}
+	locations := func(ls []*Location) {
+		for _, l := range ls {
+			location(l)
+		}
+	}
+	graph := func(g *Graph) {
+		if g == nil {
+			return
+		}
+		var node func(*Node)
+		node = func(n *Node) {
+			if n == nil {
+				return
+			}
+			location(n.Location)
+			for _, c := range n.Children {
+				node(c)
+			}
+		}
+		for _, n := range g.Nodes {
+			node(n)
+		}
+	}
+	threadFlowLocation := func(t *ThreadFlowLocation) {
+		if t != nil {
+			location(t.Location)
+			stack(t.Stack)
+		}
+	}
+	notification := func(n *Notification) {
+		if n == nil {
+			return
+		}
+		locations(n.Locations)
+		if n.Exception != nil {
+			var exception func(*Exception)
+			exception = func(e *Exception) {
+				stack(e.Stack)
+				for _, inner := range e.InnerExceptions {
+					if inner != nil {
+						exception(inner)
+					}
+				}
+			}
+			exception(n.Exception)
+		}
+	}
+	invocation := func(inv *Invocation) {
+		if inv == nil {
+			return
+		}
+		add(inv.ExecutableLocation)
+		for _, l := range inv.ResponseFiles {
+			add(l)
+		}
+		add(inv.Stdin)
+		add(inv.Stdout)
+		add(inv.Stderr)
+		add(inv.StdoutStderr)
+		add(inv.WorkingDirectory)
+		for _, n := range inv.ToolConfigurationNotifications {
+			notification(n)
+		}
+		for _, n := range inv.ToolExecutionNotifications {
+			notification(n)
+		}
+	}
+	toolComponents := func(tcs ...*ToolComponent) {
+		for _, tc := range tcs {
+			if tc == nil {
+				continue
+			}
+			for _, l := range tc.Locations {
+				add(l)
+			}
+		}
+	}
+
+	for _, a := range run.Artifacts {
+		if a != nil {
+			add(a.Location)
+		}
+	}
+	if run.Tool != nil {
+		toolComponents(run.Tool.Driver)
+		toolComponents(run.Tool.Extensions...)
+	}
+	toolComponents(run.Taxonomies...)
+	toolComponents(run.Translations...)
+	toolComponents(run.Policies...)
+	if run.Conversion != nil {
+		for _, l := range run.Conversion.AnalysisToolLogFiles {
+			add(l)
+		}
+		invocation(run.Conversion.Invocation)
+	}
+	for _, inv := range run.Invocations {
+		invocation(inv)
+	}
+	for _, g := range run.Graphs {
+		graph(g)
+	}
+	for _, t := range run.ThreadFlowLocations {
+		threadFlowLocation(t)
+	}
+	if run.SpecialLocations != nil {
+		add(run.SpecialLocations.DisplayBase)
+	}
+	for _, v := range run.VersionControlProvenance {
+		if v != nil {
+			add(v.MappedTo)
+		}
+	}
+	for _, r := range run.Results {
+		if r == nil {
+			continue
+		}
+		add(r.AnalysisTarget)
+		locations(r.Locations)
+		locations(r.RelatedLocations)
+		for _, a := range r.Attachments {
+			if a != nil {
+				add(a.ArtifactLocation)
+			}
+		}
+		for _, cf := range r.CodeFlows {
+			if cf == nil {
+				continue
+			}
+			for _, tf := range cf.ThreadFlows {
+				if tf == nil {
+					continue
+				}
+				for _, t := range tf.Locations {
+					threadFlowLocation(t)
+				}
+			}
+		}
+		for _, s := range r.Stacks {
+			stack(s)
+		}
+		for _, g := range r.Graphs {
+			graph(g)
+		}
+		for _, f := range r.Fixes {
+			if f == nil {
+				continue
+			}
+			for _, c := range f.ArtifactChanges {
+				if c != nil {
+					add(c.ArtifactLocation)
+				}
+			}
+		}
+		for _, s := range r.Suppressions {
+			if s != nil {
+				location(s.Location)
+			}
+		}
+		if r.Provenance != nil {
+			for _, p := range r.Provenance.ConversionSources {
+				physical(p)
+			}
+		}
+	}
+	return locs
+}
@@ -0,0 +1,104 @@
+package sarif
+
+import (
+	"testing"
+)
+
+func TestRebase(t *testing.T) {
+	tests := []struct {
+		name      string
+		root      string
+		baseIDs   map[string]*ArtifactLocation
+		loc       ArtifactLocation
+		want      ArtifactLocation
+		rewritten int
+		wantErr   bool
+	}{
+		{
+			name:      "absolute path",
+			root:      "/home/runner/work/repo",
+			loc:       ArtifactLocation{Uri: "/home/runner/work/repo/pkg/x.go"},
+			want:      ArtifactLocation{Uri: "pkg/x.go", UriBaseId: "SRCROOT"},
+			rewritten: 1,
+		},
+		{
+			name:      "file uri under a root with trailing slash",
+			root:      "file:///home/runner/work/repo/",
+			loc:       ArtifactLocation{Uri: "file:///home/runner/work/repo/x.go"},
+			want:      ArtifactLocation{Uri: "x.go", UriBaseId: "SRCROOT"},
+			rewritten: 1,
+		},
+		{
+			name:      "outside the root",
+			root:      "/home/runner/work/repo",
+			loc:       ArtifactLocation{Uri: "/usr/lib/go/x.go"},
+			want:      ArtifactLocation{Uri: "/usr/lib/go/x.go"},
+			rewritten: 0,
+		},
+		{
+			name:      "relative without base",
+			root:      "/home/runner/work/repo",
+			loc:       ArtifactLocation{Uri: "x.go"},
+			want:      ArtifactLocation{Uri: "x.go"},
+			rewritten: 0,
+		},
+		{
+			name:      "relative to another base under the root",
+			root:      "/repo",
+			baseIDs:   map[string]*ArtifactLocation{"PKG": {Uri: "file:///repo/pkg/"}},
+			loc:       ArtifactLocation{Uri: "x.go", UriBaseId: "PKG"},
+			want:      ArtifactLocation{Uri: "pkg/x.go", UriBaseId: "SRCROOT"},
+			rewritten: 1,
+		},
+		{
+			name:      "spaces are escaped",
+			root:      "/repo",
+			loc:       ArtifactLocation{Uri: "file:///repo/a%20b.go"},
+			want:      ArtifactLocation{Uri: "a%20b.go", UriBaseId: "SRCROOT"},
+			rewritten: 1,
+		},
+		{
+			name:    "base id defined elsewhere",
+			root:    "/repo",
+			baseIDs: map[string]*ArtifactLocation{"SRCROOT": {Uri: "file:///other/"}},
+			loc:     ArtifactLocation{Uri: "/repo/x.go"},
+			wantErr: true,
+		},
+		{
+			name:    "relative root",
+			root:    "repo",
+			loc:     ArtifactLocation{Uri: "/repo/x.go"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			log := &SARIF{Runs: []*Run{{
+				OriginalUriBaseIds: tt.baseIDs,
+				Results: []*Result{{Locations: []*Location{{
+					PhysicalLocation: &PhysicalLocation{ArtifactLocation: &loc},
+				}}}},
+			}}}
+			n, err := Rebase(log, tt.root, "SRCROOT")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Rebase() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tt.rewritten {
+				t.Errorf("Rebase() = %d, want %d", n, tt.rewritten)
+			}
+			if loc.Uri != tt.want.Uri || loc.UriBaseId != tt.want.UriBaseId {
+				t.Errorf("location = %q under %q, want %q under %q", loc.Uri, loc.UriBaseId, tt.want.Uri, tt.want.UriBaseId)
+			}
+			if base := log.Runs[0].OriginalUriBaseIds["SRCROOT"]; base == nil || base.Uri[len(base.Uri)-1] != '/' {
+				t.Errorf("SRCROOT = %+v, want a uri ending in a slash", base)
+			}
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package sarif
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RuleGraph is the graph of the relationships between the rules and taxa of a
+// run, as declared by ReportingDescriptor.Relationships, with every target
+// resolved to the descriptor it refers to.
+//
+// Relationships of the kinds "subset" and "superset" between two rules or two
+// taxa form a hierarchy: a descriptor that is a subset of another is its
+// child. Relationships between a rule and a taxon map the rule onto the
+// taxonomy instead and are not part of the hierarchy.
+type RuleGraph struct {
+	run      *Run
+	nodes    []*ReportingDescriptor
+	info     map[*ReportingDescriptor]*graphNode
+	edges    []*RuleEdge
+	problems []*RelationshipProblem
+}
+
+type graphNode struct {
+	component *ToolComponent
+	kind      DescriptorKind
+	pointer   string
+	order     int
+	out, in   []*RuleEdge
+	parents   []*ReportingDescriptor
+	children  []*ReportingDescriptor
+}
+
+// RuleEdge is a relationship of a RuleGraph.
+type RuleEdge struct {
+	From, To *ReportingDescriptor
+	Kinds    []string
+	// Pointer is the JSON pointer, relative to the run, of the relationship.
+	Pointer string
+}
+
+// RelationshipProblem is a problem found while building a RuleGraph: a target
+// that cannot be resolved, contradictory relationship kinds or a cycle in the
+// hierarchy.
+type RelationshipProblem struct {
+	// Pointer is the JSON pointer, relative to the run, of the offending
+	// relationship or, for a cycle, of one of its descriptors.
+	Pointer string
+	// Detail describes the problem.
+	Detail string
+}
+
+func (p *RelationshipProblem) String() string {
+	return p.Pointer + ": " + p.Detail
+}
+
+// NewRuleGraph builds the relationship graph of the rules and taxa of run: the
+// rules of the driver and extensions, and the taxa of those and of the run's
+// taxonomies. A target without a tool component refers to a descriptor of the
+// same component as the relationship.
+func NewRuleGraph(run *Run) *RuleGraph {
+	g := &RuleGraph{run: run, info: make(map[*ReportingDescriptor]*graphNode)}
+	add := func(tc *ToolComponent, ptr string) {
+		if tc == nil {
+			return
+		}
+		for _, kind := range []DescriptorKind{RuleDescriptor, TaxonDescriptor} {
+			name := "rules"
+			if kind == TaxonDescriptor {
+				name = "taxa"
+			}
+			for i, d := range descriptors(tc, kind) {
+				if d == nil || g.info[d] != nil {
+					continue
+				}
+				g.info[d] = &graphNode{component: tc, kind: kind, pointer: fmt.Sprintf("%s/%s/%d", ptr, name, i), order: len(g.nodes)}
+				g.nodes = append(g.nodes, d)
+			}
+		}
+	}
+	if run.Tool != nil {
+		add(run.Tool.Driver, "/tool/driver")
+		for i, ext := range run.Tool.Extensions {
+			add(ext, "/tool/extensions/"+strconv.Itoa(i))
+		}
+	}
+	for i, tc := range run.Taxonomies {
+		add(tc, "/taxonomies/"+strconv.Itoa(i))
+	}
+
+	for _, d := range g.nodes {
+		n := g.info[d]
+		for i, r := range d.Relationships {
+			if r == nil {
+				continue
+			}
+			ptr := n.pointer + "/relationships/" + strconv.Itoa(i)
+			to := g.resolve(r.Target, n)
+			if to == nil {
+				g.problem(ptr, "target %s cannot be resolved", describeTarget(r.Target))
+				continue
+			}
+			if to == d {
+				g.problem(ptr, "%s is related to itself", describeDescriptor(d))
+				continue
+			}
+			e := &RuleEdge{From: d, To: to, Kinds: r.Kinds, Pointer: ptr}
+			g.edges = append(g.edges, e)
+			n.out = append(n.out, e)
+			g.info[to].in = append(g.info[to].in, e)
+			if n.kind != g.info[to].kind {
+				continue
+			}
+			if contains(r.Kinds, "subset") && !contains(r.Kinds, "superset") {
+				g.link(to, d)
+			}
+			if contains(r.Kinds, "superset") && !contains(r.Kinds, "subset") {
+				g.link(d, to)
+			}
+		}
+	}
+	g.checkKinds()
+	for _, cycle := range g.Cycles() {
+		ids := make([]string, len(cycle)+1)
+		for i, d := range cycle {
+			ids[i] = describeDescriptor(d)
+		}
+		ids[len(cycle)] = ids[0]
+		g.problem(g.info[cycle[0]].pointer, "cycle in hierarchy: %s", strings.Join(ids, " > "))
+	}
+	return g
+}
+
+// resolve returns the descriptor of the graph that ref, declared by a
+// relationship of the descriptor n, refers to.
+func (g *RuleGraph) resolve(ref *ReportingDescriptorReference, n *graphNode) *ReportingDescriptor {
+	if ref == nil {
+		return nil
+	}
+	var d *ReportingDescriptor
+	if ref.ToolComponent == nil {
+		d = findDescriptor(descriptors(n.component, n.kind), ref, true)
+	} else if d, _, _ = g.run.ResolveDescriptor(ref, RuleDescriptor); d == nil {
+		d, _, _ = g.run.ResolveDescriptor(ref, TaxonDescriptor)
+	}
+	if g.info[d] == nil {
+		return nil
+	}
+	return d
+}
+
+func (g *RuleGraph) link(parent, child *ReportingDescriptor) {
+	p, c := g.info[parent], g.info[child]
+	for _, e := range c.parents {
+		if e == parent {
+			return
+		}
+	}
+	c.parents = append(c.parents, parent)
+	p.children = append(p.children, child)
+}
+
+func (g *RuleGraph) problem(ptr, format string, args ...any) {
+	g.problems = append(g.problems, &RelationshipProblem{Pointer: ptr, Detail: fmt.Sprintf(format, args...)})
+}
+
+// inverseKinds maps each relationship kind onto the kind of the relationship
+// in the opposite direction.
+var inverseKinds = map[string]string{
+	"subset":       "superset",
+	"superset":     "subset",
+	"canPrecede":   "canFollow",
+	"canFollow":    "canPrecede",
+	"willPrecede":  "willFollow",
+	"willFollow":   "willPrecede",
+	"equal":        "equal",
+	"disjoint":     "disjoint",
+	"incomparable": "incomparable",
+	"relevant":     "relevant",
+}
+
+// contradictions lists the pairs of kinds that cannot both hold between two
+// descriptors.
+var contradictions = [][2]string{
+	{"disjoint", "subset"},
+	{"disjoint", "superset"},
+	{"disjoint", "equal"},
+	{"incomparable", "subset"},
+	{"incomparable", "superset"},
+	{"incomparable", "equal"},
+	{"willPrecede", "willFollow"},
+}
+
+// checkKinds reports pairs of descriptors whose relationships, declared in
+// either direction, contradict each other.
+func (g *RuleGraph) checkKinds() {
+	type pair struct{ a, b *ReportingDescriptor }
+	kinds := make(map[pair][]string)
+	var pairs []pair
+	first := make(map[pair]string)
+	for _, e := range g.edges {
+		p, ks := pair{e.From, e.To}, e.Kinds
+		if g.info[e.To].order < g.info[e.From].order {
+			p = pair{e.To, e.From}
+			ks = nil
+			for _, k := range e.Kinds {
+				if inv, ok := inverseKinds[k]; ok {
+					k = inv
+				}
+				ks = append(ks, k)
+			}
+		}
+		if _, ok := first[p]; !ok {
+			first[p] = e.Pointer
+			pairs = append(pairs, p)
+		}
+		kinds[p] = append(kinds[p], ks...)
+	}
+	for _, p := range pairs {
+		for _, c := range contradictions {
+			if contains(kinds[p], c[0]) && contains(kinds[p], c[1]) {
+				g.problem(first[p], "relationships between %s and %s are both %q and %q", describeDescriptor(p.a), describeDescriptor(p.b), c[0], c[1])
+			}
+		}
+	}
+}
+
+// Problems returns the problems found while building g.
+func (g *RuleGraph) Problems() []*RelationshipProblem {
+	return g.problems
+}
+
+// Edges returns all relationships of g, in the order they are declared.
+func (g *RuleGraph) Edges() []*RuleEdge {
+	return g.edges
+}
+
+// Component returns the tool component that defines d, or nil if d is not
+// part of g.
+func (g *RuleGraph) Component(d *ReportingDescriptor) *ToolComponent {
+	if n := g.info[d]; n != nil {
+		return n.component
+	}
+	return nil
+}
+
+// Relationships returns the relationships that d declares.
+func (g *RuleGraph) Relationships(d *ReportingDescriptor) []*RuleEdge {
+	if n := g.info[d]; n != nil {
+		return n.out
+	}
+	return nil
+}
+
+// Incoming returns the relationships that target d.
+func (g *RuleGraph) Incoming(d *ReportingDescriptor) []*RuleEdge {
+	if n := g.info[d]; n != nil {
+		return n.in
+	}
+	return nil
+}
+
+// Kinds returns the kinds of the relationships from one descriptor to
+// another, including those declared by to in the opposite direction with
+// their kinds inverted, e.g. "superset" for "subset".
+func (g *RuleGraph) Kinds(from, to *ReportingDescriptor) []string {
+	var kinds []string
+	add := func(k string) {
+		if !contains(kinds, k) {
+			kinds = append(kinds, k)
+		}
+	}
+	for _, e := range g.Relationships(from) {
+		if e.To == to {
+			for _, k := range e.Kinds {
+				add(k)
+			}
+		}
+	}
+	for _, e := range g.Relationships(to) {
+		if e.To == from {
+			for _, k := range e.Kinds {
+				if inv, ok := inverseKinds[k]; ok {
+					k = inv
+				}
+				add(k)
+			}
+		}
+	}
+	return kinds
+}
+
+// Parents returns the descriptors d is a direct child of in the hierarchy.
+func (g *RuleGraph) Parents(d *ReportingDescriptor) []*ReportingDescriptor {
+	if n := g.info[d]; n != nil {
+		return n.parents
+	}
+	return nil
+}
+
+// Children returns the direct children of d in the hierarchy.
+func (g *RuleGraph) Children(d *ReportingDescriptor) []*ReportingDescriptor {
+	if n := g.info[d]; n != nil {
+		return n.children
+	}
+	return nil
+}
+
+// Ancestors returns the ancestors of d in the hierarchy, nearest first.
+func (g *RuleGraph) Ancestors(d *ReportingDescriptor) []*ReportingDescriptor {
+	return g.reach(d, g.Parents)
+}
+
+// Descendants returns the descendants of d in the hierarchy, nearest first.
+func (g *RuleGraph) Descendants(d *ReportingDescriptor) []*ReportingDescriptor {
+	return g.reach(d, g.Children)
+}
+
+// reach returns the descriptors reachable from d through next, breadth first
+// and excluding d itself.
+func (g *RuleGraph) reach(d *ReportingDescriptor, next func(*ReportingDescriptor) []*ReportingDescriptor) []*ReportingDescriptor {
+	seen := map[*ReportingDescriptor]bool{d: true}
+	var out []*ReportingDescriptor
+	queue := []*ReportingDescriptor{d}
+	for len(queue) > 0 {
+		for _, n := range next(queue[0]) {
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+				queue = append(queue, n)
+			}
+		}
+		queue = queue[1:]
+	}
+	return out
+}
+
+// Cycles returns the cycles of the hierarchy of g, each as the descriptors it
+// consists of in graph order.
+func (g *RuleGraph) Cycles() [][]*ReportingDescriptor {
+	// Tarjan's strongly connected components
+	index := make(map[*ReportingDescriptor]int)
+	low := make(map[*ReportingDescriptor]int)
+	onStack := make(map[*ReportingDescriptor]bool)
+	var stack []*ReportingDescriptor
+	var cycles [][]*ReportingDescriptor
+	var connect func(d *ReportingDescriptor)
+	connect = func(d *ReportingDescriptor) {
+		index[d] = len(index)
+		low[d] = index[d]
+		stack = append(stack, d)
+		onStack[d] = true
+		for _, p := range g.info[d].parents {
+			if _, ok := index[p]; !ok {
+				connect(p)
+				if low[p] < low[d] {
+					low[d] = low[p]
+				}
+			} else if onStack[p] && index[p] < low[d] {
+				low[d] = index[p]
+			}
+		}
+		if low[d] != index[d] {
+			return
+		}
+		var scc []*ReportingDescriptor
+		for {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[n] = false
+			scc = append(scc, n)
+			if n == d {
+				break
+			}
+		}
+		if len(scc) > 1 {
+			sort.Slice(scc, func(i, j int) bool { return g.info[scc[i]].order < g.info[scc[j]].order })
+			cycles = append(cycles, scc)
+		}
+	}
+	for _, d := range g.nodes {
+		if _, ok := index[d]; !ok {
+			connect(d)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return g.info[cycles[i][0]].order < g.info[cycles[j][0]].order })
+	return cycles
+}
+
+// RollUp is the number of results that fall under a rule or taxon.
+type RollUp struct {
+	Descriptor *ReportingDescriptor
+	Component  *ToolComponent
+	// Direct is the number of results attributed to the descriptor itself.
+	Direct int
+	// Results are the results attributed to the descriptor or to any of its
+	// descendants, each once, in run order.
+	Results []*Result
+}
+
+// RollUp attributes each result of the run to its rule, to the taxa it
+// references and to the taxa its rule is mapped onto, other than by a
+// "disjoint" or "incomparable" relationship, and then rolls it up to all
+// their ancestors. It returns the descriptors with at least one result, in
+// graph order.
+func (g *RuleGraph) RollUp() []*RollUp {
+	rollups := make(map[*ReportingDescriptor]*RollUp)
+	for _, result := range g.run.Results {
+		if result == nil {
+			continue
+		}
+		var direct []*ReportingDescriptor
+		rule, _, _ := g.run.RuleFor(result)
+		if g.info[rule] != nil {
+			direct = append(direct, rule)
+			for _, e := range g.info[rule].out {
+				if g.info[e.To].kind == TaxonDescriptor && !contains(e.Kinds, "disjoint") && !contains(e.Kinds, "incomparable") {
+					direct = append(direct, e.To)
+				}
+			}
+		}
+		for _, ref := range result.Taxa {
+			if ref == nil {
+				continue
+			}
+			if t, _, _ := g.run.ResolveDescriptor(ref, TaxonDescriptor); g.info[t] != nil {
+				direct = append(direct, t)
+			}
+		}
+		seen := make(map[*ReportingDescriptor]bool)
+		counted := make(map[*ReportingDescriptor]bool)
+		for _, d := range direct {
+			for _, a := range append([]*ReportingDescriptor{d}, g.Ancestors(d)...) {
+				r := rollups[a]
+				if r == nil {
+					r = &RollUp{Descriptor: a, Component: g.info[a].component}
+					rollups[a] = r
+				}
+				if a == d && !counted[d] {
+					counted[d] = true
+					r.Direct++
+				}
+				if !seen[a] {
+					seen[a] = true
+					r.Results = append(r.Results, result)
+				}
+			}
+		}
+	}
+	var out []*RollUp
+	for _, d := range g.nodes {
+		if r := rollups[d]; r != nil {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func describeDescriptor(d *ReportingDescriptor) string {
+	if d.Id != "" {
+		return d.Id
+	}
+	return d.Guid
+}
+
+func describeTarget(ref *ReportingDescriptorReference) string {
+	if ref == nil {
+		return "<nil>"
+	}
+	s := describeRef(ref)
+	if ref.ToolComponent != nil {
+		name := ref.ToolComponent.Name
+		if name == "" {
+			name = ref.ToolComponent.Guid
+		}
+		s += " in " + strconv.Quote(name)
+	}
+	return s
+}
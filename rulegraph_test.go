@@ -0,0 +1,116 @@
+package sarif
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newGraphTestRun returns a run whose driver has the rules
+//
+//	A > B > C, with B also a child of D through a superset relationship of D
+//
+// a rule E in a cycle with F, and a rule G with problematic relationships.
+func newGraphTestRun() *Run {
+	rel := func(id string, kinds ...string) *ReportingDescriptorRelationship {
+		return &ReportingDescriptorRelationship{Target: &ReportingDescriptorReference{Id: id, Index: -1}, Kinds: kinds}
+	}
+	return &Run{
+		Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{
+			{Id: "A"},
+			{Id: "B", Relationships: []*ReportingDescriptorRelationship{rel("A", "subset")}},
+			{Id: "C", Relationships: []*ReportingDescriptorRelationship{rel("B", "subset")}},
+			{Id: "D", Relationships: []*ReportingDescriptorRelationship{rel("B", "superset")}},
+			{Id: "E", Relationships: []*ReportingDescriptorRelationship{rel("F", "subset")}},
+			{Id: "F", Relationships: []*ReportingDescriptorRelationship{rel("E", "subset")}},
+			{Id: "G", Relationships: []*ReportingDescriptorRelationship{
+				rel("missing", "relevant"),
+				rel("G", "equal"),
+				rel("A", "disjoint"),
+			}},
+		}}},
+		Results: []*Result{
+			{RuleId: "C", RuleIndex: 2},
+			{RuleId: "B", RuleIndex: 1},
+			{RuleId: "A", RuleIndex: 0},
+		},
+	}
+}
+
+func graphIDs(ds []*ReportingDescriptor) []string {
+	ids := []string{}
+	for _, d := range ds {
+		ids = append(ids, d.Id)
+	}
+	return ids
+}
+
+func TestRuleGraphHierarchy(t *testing.T) {
+	run := newGraphTestRun()
+	g := NewRuleGraph(run)
+	rule := func(id string) *ReportingDescriptor {
+		for _, r := range run.Tool.Driver.Rules {
+			if r.Id == id {
+				return r
+			}
+		}
+		t.Fatalf("no rule %s", id)
+		return nil
+	}
+	tests := []struct {
+		name string
+		got  []*ReportingDescriptor
+		want []string
+	}{
+		{"parents of B", g.Parents(rule("B")), []string{"A", "D"}},
+		{"children of A", g.Children(rule("A")), []string{"B"}},
+		{"ancestors of C", g.Ancestors(rule("C")), []string{"B", "A", "D"}},
+		{"descendants of D", g.Descendants(rule("D")), []string{"B", "C"}},
+		{"ancestors of a root", g.Ancestors(rule("A")), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphIDs(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if kinds := g.Kinds(rule("B"), rule("D")); !reflect.DeepEqual(kinds, []string{"subset"}) {
+		t.Errorf("Kinds(B, D) = %q, want the inverse of D's superset", kinds)
+	}
+	if cycles := g.Cycles(); len(cycles) != 1 || !reflect.DeepEqual(graphIDs(cycles[0]), []string{"E", "F"}) {
+		t.Errorf("Cycles() = %v, want [E F]", cycles)
+	}
+}
+
+func TestRuleGraphProblems(t *testing.T) {
+	g := NewRuleGraph(newGraphTestRun())
+	var got []string
+	for _, p := range g.Problems() {
+		got = append(got, p.Pointer)
+	}
+	want := []string{
+		"/tool/driver/rules/6/relationships/0",
+		"/tool/driver/rules/6/relationships/1",
+		"/tool/driver/rules/4",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems at %q, want %q", got, want)
+	}
+}
+
+func TestRuleGraphRollUp(t *testing.T) {
+	g := NewRuleGraph(newGraphTestRun())
+	type rollUp struct {
+		id      string
+		direct  int
+		results int
+	}
+	want := []rollUp{{"A", 1, 3}, {"B", 1, 2}, {"C", 1, 1}, {"D", 0, 2}}
+	var got []rollUp
+	for _, r := range g.RollUp() {
+		got = append(got, rollUp{r.Descriptor.Id, r.Direct, len(r.Results)})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RollUp() = %v, want %v", got, want)
+	}
+}
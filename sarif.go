@@ -5710,6 +5710,7 @@ func (strct *ReportingConfiguration) MarshalJSON() ([]byte, error) {
 }
 
 func (strct *ReportingConfiguration) UnmarshalJSON(b []byte) error {
+	enabledReceived := false
 	var jsonMap map[string]json.RawMessage
 	if err := json.Unmarshal(b, &jsonMap); err != nil {
 		return err
@@ -5721,6 +5722,7 @@ func (strct *ReportingConfiguration) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Enabled); err != nil {
 				return err
 			}
+			enabledReceived = true
 		case "level":
 			if err := json.Unmarshal([]byte(v), &strct.Level); err != nil {
 				return err
@@ -5741,6 +5743,10 @@ func (strct *ReportingConfiguration) UnmarshalJSON(b []byte) error {
 			return fmt.Errorf("additional property not allowed: \"" + k + "\"")
 		}
 	}
+	// enabled defaults to true when absent
+	if !enabledReceived {
+		strct.Enabled = true
+	}
 	return nil
 }
 
@@ -6528,6 +6534,7 @@ func (strct *Result) MarshalJSON() ([]byte, error) {
 
 func (strct *Result) UnmarshalJSON(b []byte) error {
 	messageReceived := false
+	rankReceived := false
 	var jsonMap map[string]json.RawMessage
 	if err := json.Unmarshal(b, &jsonMap); err != nil {
 		return err
@@ -6616,6 +6623,7 @@ func (strct *Result) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Rank); err != nil {
 				return err
 			}
+			rankReceived = true
 		case "relatedLocations":
 			if err := json.Unmarshal([]byte(v), &strct.RelatedLocations); err != nil {
 				return err
@@ -6664,6 +6672,10 @@ func (strct *Result) UnmarshalJSON(b []byte) error {
 	if !messageReceived {
 		return errors.New("\"message\" is required but was not present")
 	}
+	// rank defaults to -1 when absent
+	if !rankReceived {
+		strct.Rank = -1
+	}
 	return nil
 }
 
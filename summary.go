@@ -0,0 +1,269 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// SummaryTopN is the number of rules and files listed in the TopRules and
+// TopFiles of a Summary.
+const SummaryTopN = 10
+
+// Summary holds statistics about the results of a SARIF log.
+type Summary struct {
+	// Results is the total number of results.
+	Results int `json:"results"`
+	// Runs summarizes each run of the log, in log order.
+	Runs []*RunSummary `json:"runs"`
+
+	// The following count results by the tool that produced them, their
+	// rule id, effective level, kind, baseline state, suppression status,
+	// the artifact of their first location and the directory of that
+	// artifact. Kinds default to "fail", absent baseline states are counted
+	// as "unspecified", and the suppression status is one of "suppressed",
+	// "underReview", "rejected" and "unsuppressed".
+	ByTool          map[string]int `json:"byTool"`
+	ByRule          map[string]int `json:"byRule"`
+	ByLevel         map[string]int `json:"byLevel"`
+	ByKind          map[string]int `json:"byKind"`
+	ByBaselineState map[string]int `json:"byBaselineState"`
+	BySuppression   map[string]int `json:"bySuppression"`
+	ByArtifact      map[string]int `json:"byArtifact"`
+	ByDirectory     map[string]int `json:"byDirectory"`
+
+	// TopRules and TopFiles are the SummaryTopN rules and artifacts with
+	// the most results.
+	TopRules []Count `json:"topRules"`
+	TopFiles []Count `json:"topFiles"`
+}
+
+// RunSummary holds statistics about the results of a single run.
+type RunSummary struct {
+	Tool    string         `json:"tool"`
+	Version string         `json:"version,omitempty"`
+	Results int            `json:"results"`
+	ByLevel map[string]int `json:"byLevel"`
+}
+
+// Count is a key of a Summary with its number of results.
+type Count struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// Summarize computes the statistics of the results of log.
+func Summarize(log *SARIF) *Summary {
+	s := &Summary{
+		ByTool:          make(map[string]int),
+		ByRule:          make(map[string]int),
+		ByLevel:         make(map[string]int),
+		ByKind:          make(map[string]int),
+		ByBaselineState: make(map[string]int),
+		BySuppression:   make(map[string]int),
+		ByArtifact:      make(map[string]int),
+		ByDirectory:     make(map[string]int),
+	}
+	for _, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		rs := &RunSummary{ByLevel: make(map[string]int)}
+		if run.Tool != nil && run.Tool.Driver != nil {
+			rs.Tool = run.Tool.Driver.Name
+			rs.Version = withDefault(run.Tool.Driver.SemanticVersion, run.Tool.Driver.Version)
+		}
+		s.Runs = append(s.Runs, rs)
+		for _, result := range run.Results {
+			if result == nil {
+				continue
+			}
+			level := run.EffectiveLevel(result)
+			rs.Results++
+			rs.ByLevel[level]++
+			s.Results++
+			s.ByTool[rs.Tool]++
+			s.ByLevel[level]++
+			s.ByKind[withDefault(result.Kind, "fail")]++
+			s.ByBaselineState[withDefault(result.BaselineState, "unspecified")]++
+			s.BySuppression[suppressionStatus(result)]++
+			if id := run.ruleID(result); id != "" {
+				s.ByRule[id]++
+			}
+			if uris := run.resultURIs(result); len(uris) > 0 {
+				s.ByArtifact[uris[0]]++
+				s.ByDirectory[path.Dir(uris[0])]++
+			}
+		}
+	}
+	s.TopRules = Top(s.ByRule, SummaryTopN)
+	s.TopFiles = Top(s.ByArtifact, SummaryTopN)
+	return s
+}
+
+// suppressionStatus classifies result for Summary.BySuppression.
+func suppressionStatus(result *Result) string {
+	switch {
+	case len(result.Suppressions) == 0:
+		return "unsuppressed"
+	case result.IsSuppressed():
+		return "suppressed"
+	}
+	for _, s := range result.Suppressions {
+		if s != nil && s.State == "underReview" {
+			return "underReview"
+		}
+	}
+	return "rejected"
+}
+
+// Top returns the n keys of counts with the highest counts, highest first and
+// ties in key order. If n is negative all keys are returned.
+func Top(counts map[string]int, n int) []Count {
+	top := make([]Count, 0, len(counts))
+	for k, c := range counts {
+		top = append(top, Count{Key: k, Count: c})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Key < top[j].Key
+	})
+	if n >= 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// WriteJSON writes s to w as indented JSON.
+func (s *Summary) WriteJSON(w io.Writer) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// summaryTable is a section of the text and Markdown renderings of a Summary.
+type summaryTable struct {
+	title  string
+	header []string
+	rows   [][]string
+}
+
+func (s *Summary) tables() []summaryTable {
+	runs := summaryTable{title: "Runs", header: []string{"Run", "Tool", "Results", "Errors", "Warnings", "Notes"}}
+	for i, rs := range s.Runs {
+		tool := rs.Tool
+		if rs.Version != "" {
+			tool += " " + rs.Version
+		}
+		runs.rows = append(runs.rows, []string{
+			strconv.Itoa(i), tool, strconv.Itoa(rs.Results),
+			strconv.Itoa(rs.ByLevel["error"]), strconv.Itoa(rs.ByLevel["warning"]), strconv.Itoa(rs.ByLevel["note"]),
+		})
+	}
+	counts := func(title, key string, counts []Count) summaryTable {
+		t := summaryTable{title: title, header: []string{key, "Results"}}
+		for _, c := range counts {
+			t.rows = append(t.rows, []string{c.Key, strconv.Itoa(c.Count)})
+		}
+		return t
+	}
+	return []summaryTable{
+		runs,
+		counts("Levels", "Level", Top(s.ByLevel, -1)),
+		counts("Kinds", "Kind", Top(s.ByKind, -1)),
+		counts("Baseline states", "State", Top(s.ByBaselineState, -1)),
+		counts("Suppression", "Status", Top(s.BySuppression, -1)),
+		counts("Tools", "Tool", Top(s.ByTool, -1)),
+		counts("Noisiest rules", "Rule", s.TopRules),
+		counts("Noisiest files", "File", s.TopFiles),
+		counts("Noisiest directories", "Directory", Top(s.ByDirectory, SummaryTopN)),
+	}
+}
+
+// WriteText writes s to w as plain text tables.
+func (s *Summary) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d %s in %d %s\n", s.Results, plural(s.Results, "result", "results"), len(s.Runs), plural(len(s.Runs), "run", "runs"))
+	for _, t := range s.tables() {
+		if len(t.rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s\n", t.title)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteMarkdown writes s to w as Markdown tables, suitable for the job
+// summary of a CI system.
+func (s *Summary) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%d %s** in %d %s\n", s.Results, plural(s.Results, "result", "results"), len(s.Runs), plural(len(s.Runs), "run", "runs"))
+	for _, t := range s.tables() {
+		if len(t.rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", t.title)
+		writeMarkdownRow(&b, t.header)
+		seps := make([]string, len(t.header))
+		for i, h := range t.header {
+			seps[i] = "---"
+			if numericColumns[h] {
+				seps[i] = "---:"
+			}
+		}
+		writeMarkdownRow(&b, seps)
+		for _, row := range t.rows {
+			writeMarkdownRow(&b, row)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// numericColumns are the columns of summary tables that are aligned right.
+var numericColumns = map[string]bool{"Results": true, "Errors": true, "Warnings": true, "Notes": true}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, c := range cells {
+		b.WriteString(" " + escapeMarkdownCell(c) + " |")
+	}
+	b.WriteString("\n")
+}
+
+// escapeMarkdownCell escapes the characters of s that would break a Markdown
+// table cell or be taken as formatting.
+func escapeMarkdownCell(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '|', '\\', '*', '_', '`', '[', ']', '<', '>':
+			b.WriteByte('\\')
+		case '\n', '\r':
+			r = ' '
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
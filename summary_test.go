@@ -0,0 +1,91 @@
+package sarif
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(readTestLog(t, "sample.sarif"))
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"results", s.Results, 3},
+		{"levels", s.ByLevel, map[string]int{"error": 1, "warning": 1, "note": 1}},
+		{"kinds", s.ByKind, map[string]int{"fail": 3}},
+		{"baseline states", s.ByBaselineState, map[string]int{"unspecified": 3}},
+		{"suppression", s.BySuppression, map[string]int{"suppressed": 1, "unsuppressed": 2}},
+		{"directories", s.ByDirectory, map[string]int{"cmd": 1, "internal/auth": 1, "internal/db": 1}},
+		{"runs", *s.Runs[0], RunSummary{Tool: "gosec", Version: "2.18.0", Results: 3, ByLevel: map[string]int{"error": 1, "warning": 1, "note": 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppressionStatus(t *testing.T) {
+	tests := []struct {
+		states []string
+		want   string
+	}{
+		{nil, "unsuppressed"},
+		{[]string{"accepted"}, "suppressed"},
+		{[]string{"accepted", "underReview"}, "underReview"},
+		{[]string{"rejected"}, "rejected"},
+	}
+	for _, tt := range tests {
+		r := &Result{}
+		for _, s := range tt.states {
+			r.Suppressions = append(r.Suppressions, &Suppression{Kind: "external", State: s})
+		}
+		if got := suppressionStatus(r); got != tt.want {
+			t.Errorf("suppressionStatus(%q) = %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
+
+func TestTop(t *testing.T) {
+	counts := map[string]int{"a": 1, "b": 3, "c": 3, "d": 2}
+	tests := []struct {
+		n    int
+		want []Count
+	}{
+		{2, []Count{{"b", 3}, {"c", 3}}},
+		{-1, []Count{{"b", 3}, {"c", 3}, {"d", 2}, {"a", 1}}},
+		{0, []Count{}},
+	}
+	for _, tt := range tests {
+		if got := Top(counts, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Top(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryGolden(t *testing.T) {
+	s := Summarize(readTestLog(t, "sample.sarif"))
+	tests := []struct {
+		golden string
+		write  func(io.Writer) error
+	}{
+		{"summary.txt", s.WriteText},
+		{"summary.md", s.WriteMarkdown},
+		{"summary.json", s.WriteJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
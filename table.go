@@ -0,0 +1,260 @@
+package sarif
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultTableColumns are the columns WriteTable writes if TableOptions.Columns
+// is empty.
+var DefaultTableColumns = []string{
+	"tool", "ruleId", "ruleName", "level", "effectiveLevel", "message",
+	"uri", "startLine", "startColumn", "endLine", "endColumn",
+	"fingerprints", "baselineState", "suppressionJustification",
+}
+
+// LocationStrategy states how WriteTable flattens results with several
+// locations.
+type LocationStrategy int
+
+const (
+	// FirstLocation writes one row per result with its first location.
+	FirstLocation LocationStrategy = iota
+	// EachLocation writes one row per location of a result, repeating the
+	// other columns.
+	EachLocation
+	// JoinLocations writes one row per result, with the values of each
+	// location column joined by semicolons.
+	JoinLocations
+)
+
+// TableOptions configures WriteTable.
+type TableOptions struct {
+	// Columns are the columns to write, in order; DefaultTableColumns if
+	// empty. Besides those of DefaultTableColumns, the columns "runIndex",
+	// "resultIndex", "kind", "guid", "correlationGuid", "rank", "suppressed"
+	// and "tags" are known, and "properties.<key>" selects a key of the
+	// result's property bag.
+	Columns []string
+	// Comma is the field delimiter, e.g. '\t' for TSV; ',' if zero.
+	Comma rune
+	// Locations states how results with several locations are flattened.
+	Locations LocationStrategy
+	// NoHeader omits the header row.
+	NoHeader bool
+	// EscapeFormulas prefixes cells that a spreadsheet would evaluate as a
+	// formula, those starting with =, +, -, @, tab or carriage return, with
+	// a single quote.
+	EscapeFormulas bool
+}
+
+// tableRow is the data a row of a table is rendered from.
+type tableRow struct {
+	run                   *Run
+	runIndex, resultIndex int
+	result                *Result
+	locations             []*PhysicalLocation
+}
+
+// tableColumns maps the known columns onto their values.
+var tableColumns = map[string]func(r *tableRow) string{
+	"runIndex":    func(r *tableRow) string { return strconv.Itoa(r.runIndex) },
+	"resultIndex": func(r *tableRow) string { return strconv.Itoa(r.resultIndex) },
+	"tool": func(r *tableRow) string {
+		if r.run.Tool != nil && r.run.Tool.Driver != nil {
+			return r.run.Tool.Driver.Name
+		}
+		return ""
+	},
+	"ruleId": func(r *tableRow) string { return r.run.ruleID(r.result) },
+	"ruleName": func(r *tableRow) string {
+		if rule := r.run.rule(r.result); rule != nil {
+			return rule.Name
+		}
+		return ""
+	},
+	"level":          func(r *tableRow) string { return r.result.Level },
+	"effectiveLevel": func(r *tableRow) string { return r.run.EffectiveLevel(r.result) },
+	"kind":           func(r *tableRow) string { return withDefault(r.result.Kind, "fail") },
+	"message": func(r *tableRow) string {
+		msg, err := r.run.FormatMessage(r.result, false)
+		if err != nil {
+			return ""
+		}
+		return msg
+	},
+	"uri": locationColumn(func(run *Run, pl *PhysicalLocation) string {
+		return run.displayURI(pl.ArtifactLocation)
+	}),
+	"startLine":   regionColumn(func(reg *Region) int { return reg.StartLine }),
+	"startColumn": regionColumn(func(reg *Region) int { return reg.StartColumn }),
+	"endLine": regionColumn(func(reg *Region) int {
+		if reg.StartLine > 0 {
+			return withDefaultInt(reg.EndLine, reg.StartLine)
+		}
+		return 0
+	}),
+	"endColumn":       regionColumn(func(reg *Region) int { return reg.EndColumn }),
+	"guid":            func(r *tableRow) string { return r.result.Guid },
+	"correlationGuid": func(r *tableRow) string { return r.result.CorrelationGuid },
+	"rank": func(r *tableRow) string {
+		if r.result.Rank < 0 {
+			return ""
+		}
+		return strconv.FormatFloat(r.result.Rank, 'f', -1, 64)
+	},
+	"fingerprints": func(r *tableRow) string {
+		var fps []string
+		for _, k := range sortedKeys(r.result.Fingerprints) {
+			fps = append(fps, k+"="+r.result.Fingerprints[k])
+		}
+		for _, k := range sortedKeys(r.result.PartialFingerprints) {
+			fps = append(fps, k+"="+r.result.PartialFingerprints[k])
+		}
+		return strings.Join(fps, ";")
+	},
+	"baselineState": func(r *tableRow) string { return r.result.BaselineState },
+	"suppressed":    func(r *tableRow) string { return strconv.FormatBool(r.result.IsSuppressed()) },
+	"suppressionJustification": func(r *tableRow) string {
+		var js []string
+		for _, s := range r.result.Suppressions {
+			if s != nil && s.Justification != "" {
+				js = append(js, s.Justification)
+			}
+		}
+		return strings.Join(js, ";")
+	},
+	"tags": func(r *tableRow) string {
+		if r.result.Properties == nil {
+			return ""
+		}
+		return strings.Join(r.result.Properties.Tags, ",")
+	},
+}
+
+// locationColumn builds a column from a value of each location of a row,
+// joined by semicolons if the row has several.
+func locationColumn(value func(run *Run, pl *PhysicalLocation) string) func(r *tableRow) string {
+	return func(r *tableRow) string {
+		values := make([]string, len(r.locations))
+		for i, pl := range r.locations {
+			values[i] = value(r.run, pl)
+		}
+		return strings.Join(values, ";")
+	}
+}
+
+func regionColumn(value func(reg *Region) int) func(r *tableRow) string {
+	return locationColumn(func(_ *Run, pl *PhysicalLocation) string {
+		if pl.Region == nil {
+			return ""
+		}
+		if n := value(pl.Region); n > 0 {
+			return strconv.Itoa(n)
+		}
+		return ""
+	})
+}
+
+// propertyColumn returns the value of key in the property bag of the result of
+// r; values that are not strings are written as JSON.
+func propertyColumn(key string) func(r *tableRow) string {
+	return func(r *tableRow) string {
+		if r.result.Properties == nil {
+			return ""
+		}
+		v, ok := r.result.Properties.AdditionalProperties[key]
+		if !ok || v == nil {
+			return ""
+		}
+		if s, ok := v.(string); ok {
+			return s
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+// WriteTable writes the results of log to w as CSV, or as TSV or another
+// delimited format depending on opts.Comma, one row per result with the
+// columns of opts, preceded by a header row. opts may be nil.
+func WriteTable(w io.Writer, log *SARIF, opts *TableOptions) error {
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = DefaultTableColumns
+	}
+	values := make([]func(r *tableRow) string, len(columns))
+	for i, c := range columns {
+		if strings.HasPrefix(c, "properties.") {
+			values[i] = propertyColumn(strings.TrimPrefix(c, "properties."))
+		} else if values[i] = tableColumns[c]; values[i] == nil {
+			return fmt.Errorf("unknown column %q", c)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if !opts.NoHeader {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(columns))
+	write := func(r *tableRow) error {
+		for i, value := range values {
+			record[i] = value(r)
+			if opts.EscapeFormulas && record[i] != "" && strings.ContainsRune("=+-@\t\r", rune(record[i][0])) {
+				if _, err := strconv.ParseFloat(record[i], 64); err != nil {
+					record[i] = "'" + record[i]
+				}
+			}
+		}
+		return cw.Write(record)
+	}
+	for i, run := range log.Runs {
+		if run == nil {
+			continue
+		}
+		for j, result := range run.Results {
+			if result == nil {
+				continue
+			}
+			var locations []*PhysicalLocation
+			for _, loc := range result.Locations {
+				if loc != nil && loc.PhysicalLocation != nil && loc.PhysicalLocation.ArtifactLocation != nil {
+					locations = append(locations, loc.PhysicalLocation)
+				}
+			}
+			row := &tableRow{run: run, runIndex: i, resultIndex: j, result: result, locations: locations}
+			switch {
+			case opts.Locations == EachLocation && len(locations) > 1:
+				for _, pl := range locations {
+					row.locations = []*PhysicalLocation{pl}
+					if err := write(row); err != nil {
+						return err
+					}
+				}
+				continue
+			case opts.Locations != JoinLocations && len(locations) > 1:
+				row.locations = locations[:1]
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
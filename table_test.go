@@ -0,0 +1,60 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		golden string
+		opts   *TableOptions
+	}{
+		{"default columns", "table.csv", nil},
+		{"tsv with extra columns", "table.tsv", &TableOptions{
+			Comma:   '\t',
+			Columns: []string{"runIndex", "resultIndex", "ruleId", "kind", "rank", "suppressed", "tags"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteTable(&buf, readTestLog(t, "sample.sarif"), tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
+
+func TestTableRank(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+		want   string
+	}{
+		{"absent", `{"message": {"text": "m"}}`, ""},
+		{"unknown", `{"message": {"text": "m"}, "rank": -1}`, ""},
+		{"zero", `{"message": {"text": "m"}, "rank": 0}`, "0"},
+		{"ranked", `{"message": {"text": "m"}, "rank": 42.5}`, "42.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Result
+			if err := json.Unmarshal([]byte(tt.result), &result); err != nil {
+				t.Fatal(err)
+			}
+			log := &SARIF{Runs: []*Run{{Results: []*Result{&result}}}}
+			var buf bytes.Buffer
+			if err := WriteTable(&buf, log, &TableOptions{Columns: []string{"rank"}, NoHeader: true}); err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSuffix(buf.String(), "\n"); got != tt.want {
+				t.Errorf("rank cell = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
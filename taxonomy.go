@@ -0,0 +1,188 @@
+package sarif
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed taxonomies/*.json
+var taxonomyData embed.FS
+
+// CWE returns a taxonomy of the Common Weakness Enumeration: a subset of the
+// research view (CWE-1000) covering the weaknesses commonly reported by
+// security tools, with taxon ids such as "CWE-79". Each taxon relates to its
+// parent with a "subset" relationship, so that e.g. CWE-89 lies under CWE-943
+// and CWE-74. Each call returns a new copy.
+func CWE() *ToolComponent {
+	return loadTaxonomy("cwe.json")
+}
+
+// OWASPTop10 returns a taxonomy of the OWASP Top 10 2021 categories, with
+// taxon ids such as "A03:2021". Each category relates to the weaknesses of
+// the CWE taxonomy it includes with a "superset" relationship. Each call
+// returns a new copy.
+func OWASPTop10() *ToolComponent {
+	return loadTaxonomy("owasp-top10-2021.json")
+}
+
+// CERTC returns a taxonomy of the SEI CERT C Coding Standard: its categories,
+// with taxon ids such as "MEM", and a subset of its rules, such as "MEM30-C",
+// each related to its category with a "subset" relationship. Each call
+// returns a new copy.
+func CERTC() *ToolComponent {
+	return loadTaxonomy("cert-c.json")
+}
+
+func loadTaxonomy(name string) *ToolComponent {
+	data, err := taxonomyData.ReadFile("taxonomies/" + name)
+	if err != nil {
+		panic(err)
+	}
+	var tc ToolComponent
+	if err := json.Unmarshal(data, &tc); err != nil {
+		panic(fmt.Sprintf("taxonomies/%s: %v", name, err))
+	}
+	return &tc
+}
+
+// AddTaxonomy adds taxonomy to the taxonomies of run, unless a taxonomy with
+// the same guid, or name if it has none, is already present, and declares it
+// among the supported taxonomies of the driver. It returns the taxonomy that
+// the run now holds.
+func (run *Run) AddTaxonomy(taxonomy *ToolComponent) *ToolComponent {
+	index := -1
+	for i, tc := range run.Taxonomies {
+		if tc != nil && sameComponent(tc, taxonomy) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		index = len(run.Taxonomies)
+		run.Taxonomies = append(run.Taxonomies, taxonomy)
+	}
+	tc := run.Taxonomies[index]
+	if run.Tool != nil && run.Tool.Driver != nil {
+		driver := run.Tool.Driver
+		for _, ref := range driver.SupportedTaxonomies {
+			if ref != nil && (ref.Guid != "" && strings.EqualFold(ref.Guid, tc.Guid) || ref.Guid == "" && ref.Name == tc.Name) {
+				return tc
+			}
+		}
+		driver.SupportedTaxonomies = append(driver.SupportedTaxonomies, &ToolComponentReference{Guid: tc.Guid, Name: tc.Name, Index: index})
+	}
+	return tc
+}
+
+func sameComponent(a, b *ToolComponent) bool {
+	if a.Guid != "" || b.Guid != "" {
+		return strings.EqualFold(a.Guid, b.Guid)
+	}
+	return a.Name == b.Name
+}
+
+// AddTaxon relates strct to the taxon of taxonomy with the given id, such as
+// "CWE-79", with a relationship of the given kinds, "relevant" if none are
+// given. Results of the rule then fall under the taxon for UnderTaxon.
+func (strct *ReportingDescriptor) AddTaxon(taxonomy *ToolComponent, id string, kinds ...string) error {
+	ref, err := taxonReference(taxonomy, id)
+	if err != nil {
+		return err
+	}
+	if len(kinds) == 0 {
+		kinds = []string{"relevant"}
+	}
+	strct.Relationships = append(strct.Relationships, &ReportingDescriptorRelationship{Target: ref, Kinds: kinds})
+	return nil
+}
+
+// AddTaxon adds a reference to the taxon of taxonomy with the given id, such
+// as "CWE-79", to the taxa of strct.
+func (strct *Result) AddTaxon(taxonomy *ToolComponent, id string) error {
+	ref, err := taxonReference(taxonomy, id)
+	if err != nil {
+		return err
+	}
+	strct.Taxa = append(strct.Taxa, ref)
+	return nil
+}
+
+// taxonReference returns a reference to the taxon id of taxonomy. The
+// taxonomy is referred to by guid and name, since its index among the
+// taxonomies of a run is not known here.
+func taxonReference(taxonomy *ToolComponent, id string) (*ReportingDescriptorReference, error) {
+	for i, t := range taxonomy.Taxa {
+		if t != nil && t.Id == id {
+			return &ReportingDescriptorReference{
+				Id:            id,
+				Guid:          t.Guid,
+				Index:         i,
+				ToolComponent: &ToolComponentReference{Guid: taxonomy.Guid, Name: taxonomy.Name, Index: -1},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: taxon %q in %q", ErrDescriptorNotFound, id, taxonomy.Name)
+}
+
+// UnderTaxon matches results that fall under one of the taxa with the given
+// ids, such as "CWE-74" or "A03:2021". A result falls under the taxa it
+// references and those its rule relates to, and under all their ancestors in
+// the hierarchy of the run's RuleGraph, where a taxon is the child of another
+// if it relates to it with a "subset" relationship or if the other relates to
+// it with a "superset" one. The graph of each run is built the first time the
+// predicate meets the run. The predicate is safe for concurrent use.
+func UnderTaxon(ids ...string) Predicate {
+	var mu sync.Mutex
+	graphs := make(map[*Run]*RuleGraph)
+	return func(run *Run, result *Result) bool {
+		mu.Lock()
+		g, ok := graphs[run]
+		if !ok {
+			g = NewRuleGraph(run)
+			graphs[run] = g
+		}
+		mu.Unlock()
+		for _, ref := range run.resultTaxa(result) {
+			t, _, err := run.ResolveDescriptor(ref, TaxonDescriptor)
+			if err != nil {
+				// a taxonomy that is not part of the run
+				if contains(ids, ref.Id) {
+					return true
+				}
+				continue
+			}
+			if contains(ids, t.Id) {
+				return true
+			}
+			for _, a := range g.Ancestors(t) {
+				if contains(ids, a.Id) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// resultTaxa returns the references to the taxa of result and to those its
+// rule relates to.
+func (run *Run) resultTaxa(result *Result) []*ReportingDescriptorReference {
+	var refs []*ReportingDescriptorReference
+	for _, ref := range result.Taxa {
+		if ref != nil {
+			refs = append(refs, ref)
+		}
+	}
+	if rule := run.rule(result); rule != nil {
+		for _, r := range rule.Relationships {
+			// a target without a tool component is a rule of the same component
+			if r != nil && r.Target != nil && r.Target.ToolComponent != nil {
+				refs = append(refs, r.Target)
+			}
+		}
+	}
+	return refs
+}
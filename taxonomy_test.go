@@ -0,0 +1,98 @@
+package sarif
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEmbeddedTaxonomies(t *testing.T) {
+	tests := []struct {
+		name     string
+		taxonomy func() *ToolComponent
+		taxon    string
+	}{
+		{"CWE", CWE, "CWE-79"},
+		{"OWASP Top 10", OWASPTop10, "A03:2021"},
+		{"CERT C", CERTC, "MEM30-C"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tt.taxonomy()
+			if tc.Name == "" || tc.Guid == "" {
+				t.Errorf("taxonomy has no name or guid: %q %q", tc.Name, tc.Guid)
+			}
+			if _, err := taxonReference(tc, tt.taxon); err != nil {
+				t.Error(err)
+			}
+			if tt.taxonomy() == tc {
+				t.Error("taxonomy is not a new copy")
+			}
+		})
+	}
+}
+
+func TestAddTaxonomy(t *testing.T) {
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t"}}}
+	first := run.AddTaxonomy(CWE())
+	second := run.AddTaxonomy(CWE())
+	if first != second || len(run.Taxonomies) != 1 {
+		t.Errorf("adding a taxonomy twice gave %d taxonomies", len(run.Taxonomies))
+	}
+	if n := len(run.Tool.Driver.SupportedTaxonomies); n != 1 {
+		t.Errorf("driver supports %d taxonomies, want 1", n)
+	}
+}
+
+func TestUnderTaxon(t *testing.T) {
+	run := &Run{Tool: &Tool{Driver: &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{{Id: "SQL"}, {Id: "XSS"}}}}}
+	cwe := run.AddTaxonomy(CWE())
+	run.AddTaxonomy(OWASPTop10())
+	if err := run.Tool.Driver.Rules[0].AddTaxon(cwe, "CWE-89"); err != nil {
+		t.Fatal(err)
+	}
+	xss := &Result{RuleId: "XSS", RuleIndex: 1}
+	if err := xss.AddTaxon(cwe, "CWE-79"); err != nil {
+		t.Fatal(err)
+	}
+	sql := &Result{RuleId: "SQL", RuleIndex: 0}
+	external := &Result{RuleId: "XSS", RuleIndex: 1, Taxa: []*ReportingDescriptorReference{
+		{Id: "EXT-1", Index: -1, ToolComponent: &ToolComponentReference{Name: "elsewhere", Index: -1}},
+	}}
+	tests := []struct {
+		name   string
+		ids    []string
+		result *Result
+		want   bool
+	}{
+		{"own taxon", []string{"CWE-79"}, xss, true},
+		{"parent of own taxon", []string{"CWE-74"}, xss, true},
+		{"superset in another taxonomy", []string{"A03:2021"}, xss, true},
+		{"taxon of the rule", []string{"CWE-89"}, sql, true},
+		{"ancestor through the rule", []string{"CWE-943", "none"}, sql, true},
+		{"unrelated taxon", []string{"CWE-22"}, sql, false},
+		{"child is not an ancestor", []string{"CWE-89"}, xss, false},
+		{"taxonomy outside the run", []string{"EXT-1"}, external, true},
+		{"no taxa", []string{"CWE-79"}, &Result{RuleId: "XSS", RuleIndex: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnderTaxon(tt.ids...)(run, tt.result); got != tt.want {
+				t.Errorf("UnderTaxon(%q) = %v, want %v", tt.ids, got, tt.want)
+			}
+		})
+	}
+
+	// the predicate caches the graph of each run and may be shared
+	pred := UnderTaxon("A03:2021")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !pred(run, xss) {
+				t.Error("concurrent UnderTaxon did not match")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,237 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// SetMessageString registers a global message string of strct under id, to
+// be referred to by Message.Id. It returns an error if text or markdown is not
+// a well-formed message template.
+func (strct *ToolComponent) SetMessageString(id, text, markdown string) error {
+	s, err := newMessageString(id, text, markdown)
+	if err != nil {
+		return err
+	}
+	if strct.GlobalMessageStrings == nil {
+		strct.GlobalMessageStrings = make(map[string]*MultiformatMessageString)
+	}
+	strct.GlobalMessageStrings[id] = s
+	return nil
+}
+
+// SetRuleMessageString registers a message string under id on the rule of
+// strct with the given id. It returns an error if the rule does not exist or
+// if text or markdown is not a well-formed message template.
+func (strct *ToolComponent) SetRuleMessageString(ruleID, id, text, markdown string) error {
+	for _, rule := range strct.Rules {
+		if rule != nil && rule.Id == ruleID {
+			return rule.SetMessageString(id, text, markdown)
+		}
+	}
+	return fmt.Errorf("%w: %q in %q", ErrDescriptorNotFound, ruleID, strct.Name)
+}
+
+// SetMessageString registers a message string of strct under id, to be
+// referred to by Message.Id. It returns an error if text or markdown is not a
+// well-formed message template.
+func (strct *ReportingDescriptor) SetMessageString(id, text, markdown string) error {
+	s, err := newMessageString(id, text, markdown)
+	if err != nil {
+		return err
+	}
+	if strct.MessageStrings == nil {
+		strct.MessageStrings = make(map[string]*MultiformatMessageString)
+	}
+	strct.MessageStrings[id] = s
+	return nil
+}
+
+func newMessageString(id, text, markdown string) (*MultiformatMessageString, error) {
+	if id == "" {
+		return nil, errors.New("message string id is empty")
+	}
+	if text == "" {
+		return nil, fmt.Errorf("message string %q: text is required", id)
+	}
+	for _, t := range []string{text, markdown} {
+		if _, err := placeholderCount(t); err != nil {
+			return nil, fmt.Errorf("message string %q: %w", id, err)
+		}
+	}
+	return &MultiformatMessageString{Text: text, Markdown: markdown}, nil
+}
+
+// placeholderCount returns the number of arguments template requires, that
+// is one more than its highest placeholder.
+func placeholderCount(template string) (int, error) {
+	n := 0
+	err := scanTemplate(template, func(_ string, placeholder int) error {
+		if placeholder >= n {
+			n = placeholder + 1
+		}
+		return nil
+	})
+	return n, err
+}
+
+// MessageProblemKind classifies the problems found by CheckMessages.
+type MessageProblemKind int
+
+const (
+	// MissingMessageString means a message refers to an id that is not
+	// defined for it.
+	MissingMessageString MessageProblemKind = iota
+	// UnusedMessageString means a message string is never referred to.
+	UnusedMessageString
+	// ArgumentCountMismatch means a message has a different number of
+	// arguments than its message string has placeholders.
+	ArgumentCountMismatch
+	// InvalidMessageString means a message string is not a well-formed
+	// template.
+	InvalidMessageString
+)
+
+func (k MessageProblemKind) String() string {
+	switch k {
+	case MissingMessageString:
+		return "missing message string"
+	case UnusedMessageString:
+		return "unused message string"
+	case ArgumentCountMismatch:
+		return "argument count mismatch"
+	case InvalidMessageString:
+		return "invalid message string"
+	}
+	return "MessageProblemKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// MessageProblem is a problem found by CheckMessages.
+type MessageProblem struct {
+	Kind MessageProblemKind
+	// Pointer is the JSON pointer, relative to the run, of the offending
+	// message or message string.
+	Pointer string
+	// MessageID is the id of the message string concerned.
+	MessageID string
+	// Detail describes the problem.
+	Detail string
+}
+
+func (p *MessageProblem) String() string {
+	return fmt.Sprintf("%s: %s %q: %s", p.Pointer, p.Kind, p.MessageID, p.Detail)
+}
+
+// CheckMessages checks that every result and notification message of run that
+// refers to a message string by id can be resolved, as FormatMessage does,
+// and is given exactly as many arguments as the message string requires. It
+// also reports message strings of the run's tool components and their rules
+// and notifications that no message refers to, and message strings that are
+// not well-formed templates.
+func (run *Run) CheckMessages() []*MessageProblem {
+	var problems []*MessageProblem
+	report := func(kind MessageProblemKind, ptr, id, format string, args ...any) {
+		problems = append(problems, &MessageProblem{Kind: kind, Pointer: ptr, MessageID: id, Detail: fmt.Sprintf(format, args...)})
+	}
+	used := make(map[*MultiformatMessageString]bool)
+
+	check := func(msg *Message, ptr string, lookup func(id string) *MultiformatMessageString) {
+		if msg == nil || msg.Id == "" || msg.Text != "" || msg.Markdown != "" {
+			return
+		}
+		s := lookup(msg.Id)
+		if s == nil {
+			report(MissingMessageString, ptr, msg.Id, "no message string with this id is defined")
+			return
+		}
+		used[s] = true
+		n, err := placeholderCount(s.Text)
+		if err != nil {
+			return // reported with the message string below
+		}
+		if m, err := placeholderCount(s.Markdown); err == nil && m > n {
+			n = m
+		}
+		if len(msg.Arguments) != n {
+			report(ArgumentCountMismatch, ptr, msg.Id, "message has %d arguments, message string requires %d", len(msg.Arguments), n)
+		}
+	}
+
+	for i, result := range run.Results {
+		if result == nil {
+			continue
+		}
+		rule, tc, _ := run.RuleFor(result)
+		check(result.Message, "/results/"+strconv.Itoa(i)+"/message", func(id string) *MultiformatMessageString {
+			return run.lookupMessageString(id, rule, tc)
+		})
+	}
+	for i, inv := range run.Invocations {
+		if inv == nil {
+			continue
+		}
+		lists := map[string][]*Notification{
+			"toolConfigurationNotifications": inv.ToolConfigurationNotifications,
+			"toolExecutionNotifications":     inv.ToolExecutionNotifications,
+		}
+		for _, name := range sortedKeys(lists) {
+			for j, n := range lists[name] {
+				if n == nil {
+					continue
+				}
+				var descriptor *ReportingDescriptor
+				var tc *ToolComponent
+				if n.Descriptor != nil {
+					descriptor, tc, _ = run.ResolveDescriptor(n.Descriptor, NotificationDescriptor)
+				}
+				ptr := fmt.Sprintf("/invocations/%d/%s/%d/message", i, name, j)
+				check(n.Message, ptr, func(id string) *MultiformatMessageString {
+					return run.lookupMessageString(id, descriptor, tc)
+				})
+			}
+		}
+	}
+
+	checkStrings := func(messageStrings map[string]*MultiformatMessageString, ptr string) {
+		for _, id := range sortedKeys(messageStrings) {
+			s := messageStrings[id]
+			if s == nil {
+				continue
+			}
+			p := ptr + "/" + escapePointer(id)
+			for _, t := range []string{s.Text, s.Markdown} {
+				if _, err := placeholderCount(t); err != nil {
+					report(InvalidMessageString, p, id, "%v", err)
+					break
+				}
+			}
+			if !used[s] {
+				report(UnusedMessageString, p, id, "no message refers to this message string")
+			}
+		}
+	}
+	checkComponent := func(tc *ToolComponent, ptr string) {
+		if tc == nil {
+			return
+		}
+		checkStrings(tc.GlobalMessageStrings, ptr+"/globalMessageStrings")
+		for i, rule := range tc.Rules {
+			if rule != nil {
+				checkStrings(rule.MessageStrings, fmt.Sprintf("%s/rules/%d/messageStrings", ptr, i))
+			}
+		}
+		for i, n := range tc.Notifications {
+			if n != nil {
+				checkStrings(n.MessageStrings, fmt.Sprintf("%s/notifications/%d/messageStrings", ptr, i))
+			}
+		}
+	}
+	if run.Tool != nil {
+		checkComponent(run.Tool.Driver, "/tool/driver")
+		for i, ext := range run.Tool.Extensions {
+			checkComponent(ext, "/tool/extensions/"+strconv.Itoa(i))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,116 @@
+package sarif
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholderCount(t *testing.T) {
+	tests := []struct {
+		template string
+		want     int
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"no placeholders", 0, false},
+		{"{0}", 1, false},
+		{"{2} and {0}", 3, false},
+		{"{{5}}", 0, false},
+		{"'{5}'", 0, false},
+		{"it''s {1}", 2, false},
+		{"{0", 0, true},
+		{"{a}", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			got, err := placeholderCount(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("placeholderCount() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want && !tt.wantErr {
+				t.Errorf("placeholderCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMessageString(t *testing.T) {
+	tests := []struct {
+		name               string
+		id, text, markdown string
+		wantErr            bool
+	}{
+		{"valid", "default", "Unused {0}.", "Unused `{0}`.", false},
+		{"text only", "default", "Unused {0}.", "", false},
+		{"empty id", "", "text", "", true},
+		{"empty text", "default", "", "markdown", true},
+		{"bad text", "default", "{0", "", true},
+		{"bad markdown", "default", "ok", "}", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &ToolComponent{Name: "t", Rules: []*ReportingDescriptor{{Id: "R1"}}}
+			err := tc.SetRuleMessageString("R1", tt.id, tt.text, tt.markdown)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetRuleMessageString() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tc.Rules[0].MessageStrings[tt.id].Text != tt.text {
+				t.Errorf("message string %q not registered", tt.id)
+			}
+		})
+	}
+	if err := (&ToolComponent{Name: "t"}).SetRuleMessageString("nope", "id", "text", ""); err == nil {
+		t.Error("SetRuleMessageString() on an unknown rule succeeded")
+	}
+}
+
+func TestCheckMessages(t *testing.T) {
+	run := &Run{
+		Tool: &Tool{Driver: &ToolComponent{
+			Name: "t",
+			Rules: []*ReportingDescriptor{{
+				Id: "R1",
+				MessageStrings: map[string]*MultiformatMessageString{
+					"two":    {Text: "{0} and {1}"},
+					"unused": {Text: "never"},
+					"bad":    {Text: "{0"},
+				},
+			}},
+			Notifications: []*ReportingDescriptor{{
+				Id:             "N1",
+				MessageStrings: map[string]*MultiformatMessageString{"note": {Text: "note {0}"}},
+			}},
+		}},
+		Results: []*Result{
+			{RuleId: "R1", RuleIndex: 0, Message: &Message{Id: "two", Arguments: []string{"a", "b"}}},
+			{RuleId: "R1", RuleIndex: 0, Message: &Message{Id: "two", Arguments: []string{"a"}}},
+			{RuleId: "R1", RuleIndex: 0, Message: &Message{Id: "missing"}},
+			{RuleId: "R1", RuleIndex: 0, Message: &Message{Text: "literal"}},
+		},
+		Invocations: []*Invocation{{
+			ToolExecutionNotifications: []*Notification{{
+				Descriptor: &ReportingDescriptorReference{Id: "N1", Index: 0},
+				Message:    &Message{Id: "note", Arguments: []string{"x"}},
+			}},
+		}},
+	}
+	type problem struct {
+		Kind    MessageProblemKind
+		Pointer string
+		ID      string
+	}
+	want := []problem{
+		{ArgumentCountMismatch, "/results/1/message", "two"},
+		{MissingMessageString, "/results/2/message", "missing"},
+		{InvalidMessageString, "/tool/driver/rules/0/messageStrings/bad", "bad"},
+		{UnusedMessageString, "/tool/driver/rules/0/messageStrings/bad", "bad"},
+		{UnusedMessageString, "/tool/driver/rules/0/messageStrings/unused", "unused"},
+	}
+	var got []problem
+	for _, p := range run.CheckMessages() {
+		got = append(got, problem{p.Kind, p.Pointer, p.MessageID})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckMessages() = %+v, want %+v", got, want)
+	}
+}
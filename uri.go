@@ -0,0 +1,193 @@
+package sarif
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUndefinedBaseID is returned when a uriBaseId has no usable entry in
+	// Run.OriginalUriBaseIds.
+	ErrUndefinedBaseID = errors.New("undefined uriBaseId")
+
+	// ErrBaseIDCycle is returned when the uriBaseId chain of an artifact
+	// location refers back to itself.
+	ErrBaseIDCycle = errors.New("cyclic uriBaseId")
+
+	// ErrNotUnderBase is returned by MakeRelative when the path does not lie
+	// under the requested uriBaseId.
+	ErrNotUnderBase = errors.New("path is not under uriBaseId")
+)
+
+// ResolveURI returns the absolute URI of loc. A relative Uri is resolved
+// against its UriBaseId, whose definition in Run.OriginalUriBaseIds may itself
+// be relative to another base id; the chain is followed until an absolute URI
+// is reached. A location without a Uri is resolved through the artifact its
+// Index refers to. A relative Uri without a UriBaseId is returned unchanged,
+// since the spec leaves its interpretation to the consumer.
+func (run *Run) ResolveURI(loc *ArtifactLocation) (*url.URL, error) {
+	return run.resolveURI(loc, nil)
+}
+
+func (run *Run) resolveURI(loc *ArtifactLocation, seen map[string]bool) (*url.URL, error) {
+	if loc == nil {
+		return nil, errors.New("artifact location is nil")
+	}
+	if loc.Uri == "" {
+		if artifact := run.artifactFor(loc); artifact != nil && artifact.Location != loc {
+			return run.resolveURI(artifact.Location, seen)
+		}
+		return nil, errors.New("artifact location has no uri")
+	}
+	u, err := url.Parse(loc.Uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.IsAbs() || loc.UriBaseId == "" {
+		return u, nil
+	}
+	base, err := run.resolveBaseID(loc.UriBaseId, seen)
+	if err != nil {
+		return nil, err
+	}
+	return base.ResolveReference(u), nil
+}
+
+// resolveBaseID returns the absolute URI that the uriBaseId id stands for.
+func (run *Run) resolveBaseID(id string, seen map[string]bool) (*url.URL, error) {
+	if seen[id] {
+		return nil, fmt.Errorf("%w: %q", ErrBaseIDCycle, id)
+	}
+	base, ok := run.OriginalUriBaseIds[id]
+	if !ok || base == nil || base.Uri == "" {
+		return nil, fmt.Errorf("%w: %q", ErrUndefinedBaseID, id)
+	}
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+	seen[id] = true
+	defer delete(seen, id)
+	u, err := run.resolveURI(base, seen)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsAbs() {
+		return nil, fmt.Errorf("%w: %q does not resolve to an absolute uri", ErrUndefinedBaseID, id)
+	}
+	// the spec requires base uris to end with a slash, but producers forget
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+	return u, nil
+}
+
+// artifactFor returns the run artifact that loc refers to, if any.
+func (run *Run) artifactFor(loc *ArtifactLocation) *Artifact {
+	if i := run.artifactIndex(loc); i >= 0 {
+		return run.Artifacts[i]
+	}
+	return nil
+}
+
+// artifactIndex returns the index of the run artifact that loc refers to, or
+// -1. An absent index decodes as 0, so the index is only trusted when loc has
+// no Uri, which the spec allows only alongside an index, or when the artifact
+// it refers to has the same Uri and UriBaseId. Otherwise the artifact is
+// looked up by Uri and UriBaseId.
+func (run *Run) artifactIndex(loc *ArtifactLocation) int {
+	if i := loc.Index; i >= 0 && i < len(run.Artifacts) {
+		if a := run.Artifacts[i]; a != nil && (loc.Uri == "" || sameArtifact(a.Location, loc)) {
+			return i
+		}
+	}
+	if loc.Uri == "" {
+		return -1
+	}
+	for i, a := range run.Artifacts {
+		if a != nil && sameArtifact(a.Location, loc) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameArtifact reports whether a and b have the same Uri and UriBaseId.
+func sameArtifact(a, b *ArtifactLocation) bool {
+	return a != nil && a.Uri == b.Uri && a.UriBaseId == b.UriBaseId
+}
+
+// MakeRelative returns an artifact location for absPath expressed relative to
+// the uriBaseId baseID, which must be defined in Run.OriginalUriBaseIds.
+// absPath may be a local file system path or an absolute URI. The returned Uri
+// is percent-encoded as required for a relative reference.
+func (run *Run) MakeRelative(absPath, baseID string) (*ArtifactLocation, error) {
+	base, err := run.resolveBaseID(baseID, nil)
+	if err != nil {
+		return nil, err
+	}
+	target, err := parseAbsolute(absPath)
+	if err != nil {
+		return nil, err
+	}
+	rel, ok := relativeTo(base, target)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not under %q", ErrNotUnderBase, absPath, baseID)
+	}
+	return &ArtifactLocation{Uri: rel, UriBaseId: baseID}, nil
+}
+
+// parseAbsolute parses s as an absolute URI, or failing that, as a local file
+// system path which is converted to a file URI.
+func parseAbsolute(s string) (*url.URL, error) {
+	if u, err := url.Parse(s); err == nil && u.IsAbs() && len(u.Scheme) > 1 {
+		return u, nil
+	}
+	if !filepath.IsAbs(s) && !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("%q is not an absolute path", s)
+	}
+	return fileURL(s), nil
+}
+
+// fileURL converts a local file system path to a file URI.
+func fileURL(path string) *url.URL {
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		// windows drive letter paths such as C:/src
+		p = "/" + p
+	}
+	return &url.URL{Scheme: "file", Path: p}
+}
+
+// relativeTo returns target as a percent-encoded reference relative to base,
+// and whether target lies under base at all.
+func relativeTo(base, target *url.URL) (string, bool) {
+	if !strings.EqualFold(base.Scheme, target.Scheme) || !strings.EqualFold(base.Host, target.Host) {
+		return "", false
+	}
+	basePath := base.EscapedPath()
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+	targetPath := target.EscapedPath()
+	if !strings.HasPrefix(targetPath, basePath) {
+		return "", false
+	}
+	rel := strings.TrimPrefix(targetPath, basePath)
+	if first, _, _ := strings.Cut(rel, "/"); strings.Contains(first, ":") {
+		// keep the first segment from being read as a scheme
+		rel = "./" + rel
+	}
+	if target.RawQuery != "" {
+		rel += "?" + target.RawQuery
+	}
+	if target.Fragment != "" {
+		rel += "#" + target.EscapedFragment()
+	}
+	return rel, true
+}
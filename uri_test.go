@@ -0,0 +1,133 @@
+package sarif
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveURI(t *testing.T) {
+	run := &Run{
+		OriginalUriBaseIds: map[string]*ArtifactLocation{
+			"ROOT":     {Uri: "file:///work/"},
+			"SRC":      {Uri: "src/", UriBaseId: "ROOT"},
+			"NOSLASH":  {Uri: "file:///other"},
+			"LOOP":     {Uri: "a/", UriBaseId: "LOOP"},
+			"PING":     {Uri: "a/", UriBaseId: "PONG"},
+			"PONG":     {Uri: "b/", UriBaseId: "PING"},
+			"RELATIVE": {Uri: "rel/"},
+			"EMPTY":    {},
+		},
+		Artifacts: []*Artifact{
+			{Location: &ArtifactLocation{Uri: "main.go", UriBaseId: "SRC"}},
+		},
+	}
+	tests := []struct {
+		name    string
+		loc     *ArtifactLocation
+		want    string
+		wantErr error
+	}{
+		{"absolute", &ArtifactLocation{Uri: "https://example.com/a.go", UriBaseId: "ROOT"}, "https://example.com/a.go", nil},
+		{"relative without base", &ArtifactLocation{Uri: "a.go"}, "a.go", nil},
+		{"one level", &ArtifactLocation{Uri: "a.go", UriBaseId: "ROOT"}, "file:///work/a.go", nil},
+		{"chain", &ArtifactLocation{Uri: "pkg/a.go", UriBaseId: "SRC"}, "file:///work/src/pkg/a.go", nil},
+		{"base without trailing slash", &ArtifactLocation{Uri: "a.go", UriBaseId: "NOSLASH"}, "file:///other/a.go", nil},
+		{"through artifact index", &ArtifactLocation{Index: 0}, "file:///work/src/main.go", nil},
+		{"self cycle", &ArtifactLocation{Uri: "a.go", UriBaseId: "LOOP"}, "", ErrBaseIDCycle},
+		{"two step cycle", &ArtifactLocation{Uri: "a.go", UriBaseId: "PING"}, "", ErrBaseIDCycle},
+		{"undefined base", &ArtifactLocation{Uri: "a.go", UriBaseId: "NOPE"}, "", ErrUndefinedBaseID},
+		{"base without uri", &ArtifactLocation{Uri: "a.go", UriBaseId: "EMPTY"}, "", ErrUndefinedBaseID},
+		{"relative base", &ArtifactLocation{Uri: "a.go", UriBaseId: "RELATIVE"}, "", ErrUndefinedBaseID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := run.ResolveURI(tt.loc)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ResolveURI() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.String() != tt.want {
+				t.Errorf("ResolveURI() = %s, want %s", u, tt.want)
+			}
+		})
+	}
+}
+
+func TestArtifactFor(t *testing.T) {
+	run := &Run{
+		Artifacts: []*Artifact{
+			{Location: &ArtifactLocation{Uri: "a.go"}},
+			{Location: &ArtifactLocation{Uri: "b.go", UriBaseId: "SRC"}},
+		},
+	}
+	tests := []struct {
+		name string
+		loc  *ArtifactLocation
+		want int
+	}{
+		{"index only", &ArtifactLocation{Index: 1}, 1},
+		{"index and matching uri", &ArtifactLocation{Uri: "b.go", UriBaseId: "SRC", Index: 1}, 1},
+		{"uri without index", &ArtifactLocation{Uri: "b.go", UriBaseId: "SRC"}, 1},
+		{"uri of another base", &ArtifactLocation{Uri: "b.go"}, -1},
+		{"unknown uri", &ArtifactLocation{Uri: "c.go"}, -1},
+		{"index out of range", &ArtifactLocation{Index: 2}, -1},
+		{"negative index", &ArtifactLocation{Index: -1}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := run.artifactFor(tt.loc)
+			var want *Artifact
+			if tt.want >= 0 {
+				want = run.Artifacts[tt.want]
+			}
+			if got != want {
+				t.Errorf("artifactFor() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestMakeRelative(t *testing.T) {
+	run := &Run{
+		OriginalUriBaseIds: map[string]*ArtifactLocation{
+			"ROOT": {Uri: "file:///work/"},
+			"LOOP": {Uri: "a/", UriBaseId: "LOOP"},
+		},
+	}
+	tests := []struct {
+		name    string
+		path    string
+		baseID  string
+		want    string
+		wantErr error
+	}{
+		{"path", "/work/src/a.go", "ROOT", "src/a.go", nil},
+		{"file uri", "file:///work/a%20b.go", "ROOT", "a%20b.go", nil},
+		{"escaped", "/work/a b.go", "ROOT", "a%20b.go", nil},
+		{"colon in first segment", "/work/c:d.go", "ROOT", "./c:d.go", nil},
+		{"outside the base", "/elsewhere/a.go", "ROOT", "", ErrNotUnderBase},
+		{"cyclic base", "/work/a.go", "LOOP", "", ErrBaseIDCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := run.MakeRelative(tt.path, tt.baseID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("MakeRelative() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.Uri != tt.want || loc.UriBaseId != tt.baseID {
+				t.Errorf("MakeRelative() = %q under %q, want %q under %q", loc.Uri, loc.UriBaseId, tt.want, tt.baseID)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package sarif
+
+//go:generate go run gen.go
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A Visitor's Visit method is invoked for each SARIF object encountered by
+// Walk. If the result visitor w is not nil, Walk visits each of the children
+// of the object with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(c *Cursor) (w Visitor)
+}
+
+// Cursor describes a SARIF object encountered during Walk: the object itself,
+// the object containing it and its position as a JSON pointer (RFC 6901)
+// relative to the node Walk was started from.
+type Cursor struct {
+	node    any
+	parent  any
+	pointer string
+	replace func(any)
+}
+
+// Node returns the current object, such as a *Result or a *Location.
+func (c *Cursor) Node() any { return c.node }
+
+// Parent returns the object that contains the current object, or nil for the
+// node Walk was started from.
+func (c *Cursor) Parent() any { return c.parent }
+
+// Pointer returns the JSON pointer of the current object, e.g.
+// "/runs/0/results/3/locations/0".
+func (c *Cursor) Pointer() string { return c.pointer }
+
+// Replace replaces the current object in its parent by n, which must be of the
+// same type or nil. The children of n, rather than those of the original
+// object, are walked next. Replace panics when called on the root.
+func (c *Cursor) Replace(n any) {
+	if c.replace == nil {
+		panic("sarif: cannot replace the root of a walk")
+	}
+	c.replace(n)
+	c.node = n
+}
+
+// Walk traverses the SARIF object graph rooted at node in depth-first order,
+// where node is a pointer to any of the types in this package. It starts by
+// calling v.Visit with a cursor for node; unless the returned visitor is nil
+// the walk continues with the fields of node in the order in which they are
+// declared, and the entries of maps in key order. Nil objects are skipped.
+func Walk(node any, v Visitor) {
+	if isNil(node) {
+		return
+	}
+	w := &walker{v: v}
+	w.walk(node, nil, "", nil)
+}
+
+type inspector func(*Cursor) bool
+
+func (f inspector) Visit(c *Cursor) Visitor {
+	if c != nil && f(c) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the SARIF object graph rooted at node in depth-first
+// order. It calls f with a cursor for each object; if f returns false the
+// children of that object are not visited.
+func Inspect(node any, f func(c *Cursor) bool) {
+	Walk(node, inspector(f))
+}
+
+type walker struct {
+	v Visitor
+}
+
+func (w *walker) walk(node, parent any, ptr string, replace func(any)) {
+	c := &Cursor{node: node, parent: parent, pointer: ptr, replace: replace}
+	v := w.v.Visit(c)
+	if v == nil || isNil(c.node) {
+		return
+	}
+	saved := w.v
+	w.v = v
+	w.children(c.node, ptr)
+	w.v = saved
+	v.Visit(nil)
+}
+
+// isNil reports whether n is nil or a nil pointer to one of the SARIF types.
+func isNil(n any) bool {
+	if n == nil {
+		return true
+	}
+	v := reflect.ValueOf(n)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// as converts the replacement value x to the type of the field it replaces,
+// mapping an untyped nil to the zero value.
+func as[T any](x any) T {
+	if x == nil {
+		var zero T
+		return zero
+	}
+	return x.(T)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapePointer escapes s for use as a JSON pointer reference token.
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
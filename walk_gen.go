@@ -0,0 +1,1253 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package sarif
+
+import (
+	"strconv"
+)
+
+// children walks the SARIF objects directly contained in node.
+func (w *walker) children(node any, ptr string) {
+	switch n := node.(type) {
+	case *Address:
+		w.walkAddress(n, ptr)
+	case *Artifact:
+		w.walkArtifact(n, ptr)
+	case *ArtifactChange:
+		w.walkArtifactChange(n, ptr)
+	case *ArtifactContent:
+		w.walkArtifactContent(n, ptr)
+	case *ArtifactLocation:
+		w.walkArtifactLocation(n, ptr)
+	case *Attachment:
+		w.walkAttachment(n, ptr)
+	case *CodeFlow:
+		w.walkCodeFlow(n, ptr)
+	case *ConfigurationOverride:
+		w.walkConfigurationOverride(n, ptr)
+	case *Conversion:
+		w.walkConversion(n, ptr)
+	case *Edge:
+		w.walkEdge(n, ptr)
+	case *EdgeTraversal:
+		w.walkEdgeTraversal(n, ptr)
+	case *Exception:
+		w.walkException(n, ptr)
+	case *ExternalProperties:
+		w.walkExternalProperties(n, ptr)
+	case *ExternalPropertyFileReference:
+		w.walkExternalPropertyFileReference(n, ptr)
+	case *ExternalPropertyFileReferences:
+		w.walkExternalPropertyFileReferences(n, ptr)
+	case *Fix:
+		w.walkFix(n, ptr)
+	case *Graph:
+		w.walkGraph(n, ptr)
+	case *GraphTraversal:
+		w.walkGraphTraversal(n, ptr)
+	case *Invocation:
+		w.walkInvocation(n, ptr)
+	case *Location:
+		w.walkLocation(n, ptr)
+	case *LocationRelationship:
+		w.walkLocationRelationship(n, ptr)
+	case *LogicalLocation:
+		w.walkLogicalLocation(n, ptr)
+	case *Message:
+		w.walkMessage(n, ptr)
+	case *MultiformatMessageString:
+		w.walkMultiformatMessageString(n, ptr)
+	case *Node:
+		w.walkNode(n, ptr)
+	case *Notification:
+		w.walkNotification(n, ptr)
+	case *PhysicalLocation:
+		w.walkPhysicalLocation(n, ptr)
+	case *PropertyBag:
+		w.walkPropertyBag(n, ptr)
+	case *Rectangle:
+		w.walkRectangle(n, ptr)
+	case *Region:
+		w.walkRegion(n, ptr)
+	case *Replacement:
+		w.walkReplacement(n, ptr)
+	case *ReportingConfiguration:
+		w.walkReportingConfiguration(n, ptr)
+	case *ReportingDescriptor:
+		w.walkReportingDescriptor(n, ptr)
+	case *ReportingDescriptorReference:
+		w.walkReportingDescriptorReference(n, ptr)
+	case *ReportingDescriptorRelationship:
+		w.walkReportingDescriptorRelationship(n, ptr)
+	case *Result:
+		w.walkResult(n, ptr)
+	case *ResultProvenance:
+		w.walkResultProvenance(n, ptr)
+	case *Run:
+		w.walkRun(n, ptr)
+	case *RunAutomationDetails:
+		w.walkRunAutomationDetails(n, ptr)
+	case *SARIF:
+		w.walkSARIF(n, ptr)
+	case *SpecialLocations:
+		w.walkSpecialLocations(n, ptr)
+	case *Stack:
+		w.walkStack(n, ptr)
+	case *StackFrame:
+		w.walkStackFrame(n, ptr)
+	case *Suppression:
+		w.walkSuppression(n, ptr)
+	case *ThreadFlow:
+		w.walkThreadFlow(n, ptr)
+	case *ThreadFlowLocation:
+		w.walkThreadFlowLocation(n, ptr)
+	case *Tool:
+		w.walkTool(n, ptr)
+	case *ToolComponent:
+		w.walkToolComponent(n, ptr)
+	case *ToolComponentReference:
+		w.walkToolComponentReference(n, ptr)
+	case *TranslationMetadata:
+		w.walkTranslationMetadata(n, ptr)
+	case *VersionControlDetails:
+		w.walkVersionControlDetails(n, ptr)
+	case *WebRequest:
+		w.walkWebRequest(n, ptr)
+	case *WebResponse:
+		w.walkWebResponse(n, ptr)
+	}
+}
+
+func (w *walker) walkAddress(n *Address, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkArtifact(n *Artifact, ptr string) {
+	if n.Contents != nil {
+		w.walk(n.Contents, n, ptr+"/contents", func(x any) { n.Contents = as[*ArtifactContent](x) })
+	}
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*ArtifactLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkArtifactChange(n *ArtifactChange, ptr string) {
+	if n.ArtifactLocation != nil {
+		w.walk(n.ArtifactLocation, n, ptr+"/artifactLocation", func(x any) { n.ArtifactLocation = as[*ArtifactLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Replacements {
+		if n.Replacements[i] != nil {
+			i := i
+			w.walk(n.Replacements[i], n, ptr+"/replacements"+"/"+strconv.Itoa(i), func(x any) { n.Replacements[i] = as[*Replacement](x) })
+		}
+	}
+}
+
+func (w *walker) walkArtifactContent(n *ArtifactContent, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Rendered != nil {
+		w.walk(n.Rendered, n, ptr+"/rendered", func(x any) { n.Rendered = as[*MultiformatMessageString](x) })
+	}
+}
+
+func (w *walker) walkArtifactLocation(n *ArtifactLocation, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkAttachment(n *Attachment, ptr string) {
+	if n.ArtifactLocation != nil {
+		w.walk(n.ArtifactLocation, n, ptr+"/artifactLocation", func(x any) { n.ArtifactLocation = as[*ArtifactLocation](x) })
+	}
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Rectangles {
+		if n.Rectangles[i] != nil {
+			i := i
+			w.walk(n.Rectangles[i], n, ptr+"/rectangles"+"/"+strconv.Itoa(i), func(x any) { n.Rectangles[i] = as[*Rectangle](x) })
+		}
+	}
+	for i := range n.Regions {
+		if n.Regions[i] != nil {
+			i := i
+			w.walk(n.Regions[i], n, ptr+"/regions"+"/"+strconv.Itoa(i), func(x any) { n.Regions[i] = as[*Region](x) })
+		}
+	}
+}
+
+func (w *walker) walkCodeFlow(n *CodeFlow, ptr string) {
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.ThreadFlows {
+		if n.ThreadFlows[i] != nil {
+			i := i
+			w.walk(n.ThreadFlows[i], n, ptr+"/threadFlows"+"/"+strconv.Itoa(i), func(x any) { n.ThreadFlows[i] = as[*ThreadFlow](x) })
+		}
+	}
+}
+
+func (w *walker) walkConfigurationOverride(n *ConfigurationOverride, ptr string) {
+	if n.Configuration != nil {
+		w.walk(n.Configuration, n, ptr+"/configuration", func(x any) { n.Configuration = as[*ReportingConfiguration](x) })
+	}
+	if n.Descriptor != nil {
+		w.walk(n.Descriptor, n, ptr+"/descriptor", func(x any) { n.Descriptor = as[*ReportingDescriptorReference](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkConversion(n *Conversion, ptr string) {
+	for i := range n.AnalysisToolLogFiles {
+		if n.AnalysisToolLogFiles[i] != nil {
+			i := i
+			w.walk(n.AnalysisToolLogFiles[i], n, ptr+"/analysisToolLogFiles"+"/"+strconv.Itoa(i), func(x any) { n.AnalysisToolLogFiles[i] = as[*ArtifactLocation](x) })
+		}
+	}
+	if n.Invocation != nil {
+		w.walk(n.Invocation, n, ptr+"/invocation", func(x any) { n.Invocation = as[*Invocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Tool != nil {
+		w.walk(n.Tool, n, ptr+"/tool", func(x any) { n.Tool = as[*Tool](x) })
+	}
+}
+
+func (w *walker) walkEdge(n *Edge, ptr string) {
+	if n.Label != nil {
+		w.walk(n.Label, n, ptr+"/label", func(x any) { n.Label = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkEdgeTraversal(n *EdgeTraversal, ptr string) {
+	for _, k := range sortedKeys(n.FinalState) {
+		if n.FinalState[k] != nil {
+			k := k
+			w.walk(n.FinalState[k], n, ptr+"/finalState"+"/"+escapePointer(k), func(x any) { n.FinalState[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkException(n *Exception, ptr string) {
+	for i := range n.InnerExceptions {
+		if n.InnerExceptions[i] != nil {
+			i := i
+			w.walk(n.InnerExceptions[i], n, ptr+"/innerExceptions"+"/"+strconv.Itoa(i), func(x any) { n.InnerExceptions[i] = as[*Exception](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Stack != nil {
+		w.walk(n.Stack, n, ptr+"/stack", func(x any) { n.Stack = as[*Stack](x) })
+	}
+}
+
+func (w *walker) walkExternalProperties(n *ExternalProperties, ptr string) {
+	for i := range n.Addresses {
+		if n.Addresses[i] != nil {
+			i := i
+			w.walk(n.Addresses[i], n, ptr+"/addresses"+"/"+strconv.Itoa(i), func(x any) { n.Addresses[i] = as[*Address](x) })
+		}
+	}
+	for i := range n.Artifacts {
+		if n.Artifacts[i] != nil {
+			i := i
+			w.walk(n.Artifacts[i], n, ptr+"/artifacts"+"/"+strconv.Itoa(i), func(x any) { n.Artifacts[i] = as[*Artifact](x) })
+		}
+	}
+	if n.Conversion != nil {
+		w.walk(n.Conversion, n, ptr+"/conversion", func(x any) { n.Conversion = as[*Conversion](x) })
+	}
+	if n.Driver != nil {
+		w.walk(n.Driver, n, ptr+"/driver", func(x any) { n.Driver = as[*ToolComponent](x) })
+	}
+	for i := range n.Extensions {
+		if n.Extensions[i] != nil {
+			i := i
+			w.walk(n.Extensions[i], n, ptr+"/extensions"+"/"+strconv.Itoa(i), func(x any) { n.Extensions[i] = as[*ToolComponent](x) })
+		}
+	}
+	if n.ExternalizedProperties != nil {
+		w.walk(n.ExternalizedProperties, n, ptr+"/externalizedProperties", func(x any) { n.ExternalizedProperties = as[*PropertyBag](x) })
+	}
+	for i := range n.Graphs {
+		if n.Graphs[i] != nil {
+			i := i
+			w.walk(n.Graphs[i], n, ptr+"/graphs"+"/"+strconv.Itoa(i), func(x any) { n.Graphs[i] = as[*Graph](x) })
+		}
+	}
+	for i := range n.Invocations {
+		if n.Invocations[i] != nil {
+			i := i
+			w.walk(n.Invocations[i], n, ptr+"/invocations"+"/"+strconv.Itoa(i), func(x any) { n.Invocations[i] = as[*Invocation](x) })
+		}
+	}
+	for i := range n.LogicalLocations {
+		if n.LogicalLocations[i] != nil {
+			i := i
+			w.walk(n.LogicalLocations[i], n, ptr+"/logicalLocations"+"/"+strconv.Itoa(i), func(x any) { n.LogicalLocations[i] = as[*LogicalLocation](x) })
+		}
+	}
+	for i := range n.Policies {
+		if n.Policies[i] != nil {
+			i := i
+			w.walk(n.Policies[i], n, ptr+"/policies"+"/"+strconv.Itoa(i), func(x any) { n.Policies[i] = as[*ToolComponent](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Results {
+		if n.Results[i] != nil {
+			i := i
+			w.walk(n.Results[i], n, ptr+"/results"+"/"+strconv.Itoa(i), func(x any) { n.Results[i] = as[*Result](x) })
+		}
+	}
+	for i := range n.Taxonomies {
+		if n.Taxonomies[i] != nil {
+			i := i
+			w.walk(n.Taxonomies[i], n, ptr+"/taxonomies"+"/"+strconv.Itoa(i), func(x any) { n.Taxonomies[i] = as[*ToolComponent](x) })
+		}
+	}
+	for i := range n.ThreadFlowLocations {
+		if n.ThreadFlowLocations[i] != nil {
+			i := i
+			w.walk(n.ThreadFlowLocations[i], n, ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i), func(x any) { n.ThreadFlowLocations[i] = as[*ThreadFlowLocation](x) })
+		}
+	}
+	for i := range n.Translations {
+		if n.Translations[i] != nil {
+			i := i
+			w.walk(n.Translations[i], n, ptr+"/translations"+"/"+strconv.Itoa(i), func(x any) { n.Translations[i] = as[*ToolComponent](x) })
+		}
+	}
+	for i := range n.WebRequests {
+		if n.WebRequests[i] != nil {
+			i := i
+			w.walk(n.WebRequests[i], n, ptr+"/webRequests"+"/"+strconv.Itoa(i), func(x any) { n.WebRequests[i] = as[*WebRequest](x) })
+		}
+	}
+	for i := range n.WebResponses {
+		if n.WebResponses[i] != nil {
+			i := i
+			w.walk(n.WebResponses[i], n, ptr+"/webResponses"+"/"+strconv.Itoa(i), func(x any) { n.WebResponses[i] = as[*WebResponse](x) })
+		}
+	}
+}
+
+func (w *walker) walkExternalPropertyFileReference(n *ExternalPropertyFileReference, ptr string) {
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*ArtifactLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkExternalPropertyFileReferences(n *ExternalPropertyFileReferences, ptr string) {
+	for i := range n.Addresses {
+		if n.Addresses[i] != nil {
+			i := i
+			w.walk(n.Addresses[i], n, ptr+"/addresses"+"/"+strconv.Itoa(i), func(x any) { n.Addresses[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.Artifacts {
+		if n.Artifacts[i] != nil {
+			i := i
+			w.walk(n.Artifacts[i], n, ptr+"/artifacts"+"/"+strconv.Itoa(i), func(x any) { n.Artifacts[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	if n.Conversion != nil {
+		w.walk(n.Conversion, n, ptr+"/conversion", func(x any) { n.Conversion = as[*ExternalPropertyFileReference](x) })
+	}
+	if n.Driver != nil {
+		w.walk(n.Driver, n, ptr+"/driver", func(x any) { n.Driver = as[*ExternalPropertyFileReference](x) })
+	}
+	for i := range n.Extensions {
+		if n.Extensions[i] != nil {
+			i := i
+			w.walk(n.Extensions[i], n, ptr+"/extensions"+"/"+strconv.Itoa(i), func(x any) { n.Extensions[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	if n.ExternalizedProperties != nil {
+		w.walk(n.ExternalizedProperties, n, ptr+"/externalizedProperties", func(x any) { n.ExternalizedProperties = as[*ExternalPropertyFileReference](x) })
+	}
+	for i := range n.Graphs {
+		if n.Graphs[i] != nil {
+			i := i
+			w.walk(n.Graphs[i], n, ptr+"/graphs"+"/"+strconv.Itoa(i), func(x any) { n.Graphs[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.Invocations {
+		if n.Invocations[i] != nil {
+			i := i
+			w.walk(n.Invocations[i], n, ptr+"/invocations"+"/"+strconv.Itoa(i), func(x any) { n.Invocations[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.LogicalLocations {
+		if n.LogicalLocations[i] != nil {
+			i := i
+			w.walk(n.LogicalLocations[i], n, ptr+"/logicalLocations"+"/"+strconv.Itoa(i), func(x any) { n.LogicalLocations[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.Policies {
+		if n.Policies[i] != nil {
+			i := i
+			w.walk(n.Policies[i], n, ptr+"/policies"+"/"+strconv.Itoa(i), func(x any) { n.Policies[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Results {
+		if n.Results[i] != nil {
+			i := i
+			w.walk(n.Results[i], n, ptr+"/results"+"/"+strconv.Itoa(i), func(x any) { n.Results[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.Taxonomies {
+		if n.Taxonomies[i] != nil {
+			i := i
+			w.walk(n.Taxonomies[i], n, ptr+"/taxonomies"+"/"+strconv.Itoa(i), func(x any) { n.Taxonomies[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.ThreadFlowLocations {
+		if n.ThreadFlowLocations[i] != nil {
+			i := i
+			w.walk(n.ThreadFlowLocations[i], n, ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i), func(x any) { n.ThreadFlowLocations[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.Translations {
+		if n.Translations[i] != nil {
+			i := i
+			w.walk(n.Translations[i], n, ptr+"/translations"+"/"+strconv.Itoa(i), func(x any) { n.Translations[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.WebRequests {
+		if n.WebRequests[i] != nil {
+			i := i
+			w.walk(n.WebRequests[i], n, ptr+"/webRequests"+"/"+strconv.Itoa(i), func(x any) { n.WebRequests[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+	for i := range n.WebResponses {
+		if n.WebResponses[i] != nil {
+			i := i
+			w.walk(n.WebResponses[i], n, ptr+"/webResponses"+"/"+strconv.Itoa(i), func(x any) { n.WebResponses[i] = as[*ExternalPropertyFileReference](x) })
+		}
+	}
+}
+
+func (w *walker) walkFix(n *Fix, ptr string) {
+	for i := range n.ArtifactChanges {
+		if n.ArtifactChanges[i] != nil {
+			i := i
+			w.walk(n.ArtifactChanges[i], n, ptr+"/artifactChanges"+"/"+strconv.Itoa(i), func(x any) { n.ArtifactChanges[i] = as[*ArtifactChange](x) })
+		}
+	}
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkGraph(n *Graph, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	for i := range n.Edges {
+		if n.Edges[i] != nil {
+			i := i
+			w.walk(n.Edges[i], n, ptr+"/edges"+"/"+strconv.Itoa(i), func(x any) { n.Edges[i] = as[*Edge](x) })
+		}
+	}
+	for i := range n.Nodes {
+		if n.Nodes[i] != nil {
+			i := i
+			w.walk(n.Nodes[i], n, ptr+"/nodes"+"/"+strconv.Itoa(i), func(x any) { n.Nodes[i] = as[*Node](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkGraphTraversal(n *GraphTraversal, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	for i := range n.EdgeTraversals {
+		if n.EdgeTraversals[i] != nil {
+			i := i
+			w.walk(n.EdgeTraversals[i], n, ptr+"/edgeTraversals"+"/"+strconv.Itoa(i), func(x any) { n.EdgeTraversals[i] = as[*EdgeTraversal](x) })
+		}
+	}
+	for _, k := range sortedKeys(n.ImmutableState) {
+		if n.ImmutableState[k] != nil {
+			k := k
+			w.walk(n.ImmutableState[k], n, ptr+"/immutableState"+"/"+escapePointer(k), func(x any) { n.ImmutableState[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	for _, k := range sortedKeys(n.InitialState) {
+		if n.InitialState[k] != nil {
+			k := k
+			w.walk(n.InitialState[k], n, ptr+"/initialState"+"/"+escapePointer(k), func(x any) { n.InitialState[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkInvocation(n *Invocation, ptr string) {
+	if n.ExecutableLocation != nil {
+		w.walk(n.ExecutableLocation, n, ptr+"/executableLocation", func(x any) { n.ExecutableLocation = as[*ArtifactLocation](x) })
+	}
+	for i := range n.NotificationConfigurationOverrides {
+		if n.NotificationConfigurationOverrides[i] != nil {
+			i := i
+			w.walk(n.NotificationConfigurationOverrides[i], n, ptr+"/notificationConfigurationOverrides"+"/"+strconv.Itoa(i), func(x any) { n.NotificationConfigurationOverrides[i] = as[*ConfigurationOverride](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.ResponseFiles {
+		if n.ResponseFiles[i] != nil {
+			i := i
+			w.walk(n.ResponseFiles[i], n, ptr+"/responseFiles"+"/"+strconv.Itoa(i), func(x any) { n.ResponseFiles[i] = as[*ArtifactLocation](x) })
+		}
+	}
+	for i := range n.RuleConfigurationOverrides {
+		if n.RuleConfigurationOverrides[i] != nil {
+			i := i
+			w.walk(n.RuleConfigurationOverrides[i], n, ptr+"/ruleConfigurationOverrides"+"/"+strconv.Itoa(i), func(x any) { n.RuleConfigurationOverrides[i] = as[*ConfigurationOverride](x) })
+		}
+	}
+	if n.Stderr != nil {
+		w.walk(n.Stderr, n, ptr+"/stderr", func(x any) { n.Stderr = as[*ArtifactLocation](x) })
+	}
+	if n.Stdin != nil {
+		w.walk(n.Stdin, n, ptr+"/stdin", func(x any) { n.Stdin = as[*ArtifactLocation](x) })
+	}
+	if n.Stdout != nil {
+		w.walk(n.Stdout, n, ptr+"/stdout", func(x any) { n.Stdout = as[*ArtifactLocation](x) })
+	}
+	if n.StdoutStderr != nil {
+		w.walk(n.StdoutStderr, n, ptr+"/stdoutStderr", func(x any) { n.StdoutStderr = as[*ArtifactLocation](x) })
+	}
+	for i := range n.ToolConfigurationNotifications {
+		if n.ToolConfigurationNotifications[i] != nil {
+			i := i
+			w.walk(n.ToolConfigurationNotifications[i], n, ptr+"/toolConfigurationNotifications"+"/"+strconv.Itoa(i), func(x any) { n.ToolConfigurationNotifications[i] = as[*Notification](x) })
+		}
+	}
+	for i := range n.ToolExecutionNotifications {
+		if n.ToolExecutionNotifications[i] != nil {
+			i := i
+			w.walk(n.ToolExecutionNotifications[i], n, ptr+"/toolExecutionNotifications"+"/"+strconv.Itoa(i), func(x any) { n.ToolExecutionNotifications[i] = as[*Notification](x) })
+		}
+	}
+	if n.WorkingDirectory != nil {
+		w.walk(n.WorkingDirectory, n, ptr+"/workingDirectory", func(x any) { n.WorkingDirectory = as[*ArtifactLocation](x) })
+	}
+}
+
+func (w *walker) walkLocation(n *Location, ptr string) {
+	for i := range n.Annotations {
+		if n.Annotations[i] != nil {
+			i := i
+			w.walk(n.Annotations[i], n, ptr+"/annotations"+"/"+strconv.Itoa(i), func(x any) { n.Annotations[i] = as[*Region](x) })
+		}
+	}
+	for i := range n.LogicalLocations {
+		if n.LogicalLocations[i] != nil {
+			i := i
+			w.walk(n.LogicalLocations[i], n, ptr+"/logicalLocations"+"/"+strconv.Itoa(i), func(x any) { n.LogicalLocations[i] = as[*LogicalLocation](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.PhysicalLocation != nil {
+		w.walk(n.PhysicalLocation, n, ptr+"/physicalLocation", func(x any) { n.PhysicalLocation = as[*PhysicalLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Relationships {
+		if n.Relationships[i] != nil {
+			i := i
+			w.walk(n.Relationships[i], n, ptr+"/relationships"+"/"+strconv.Itoa(i), func(x any) { n.Relationships[i] = as[*LocationRelationship](x) })
+		}
+	}
+}
+
+func (w *walker) walkLocationRelationship(n *LocationRelationship, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkLogicalLocation(n *LogicalLocation, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkMessage(n *Message, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkMultiformatMessageString(n *MultiformatMessageString, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkNode(n *Node, ptr string) {
+	for i := range n.Children {
+		if n.Children[i] != nil {
+			i := i
+			w.walk(n.Children[i], n, ptr+"/children"+"/"+strconv.Itoa(i), func(x any) { n.Children[i] = as[*Node](x) })
+		}
+	}
+	if n.Label != nil {
+		w.walk(n.Label, n, ptr+"/label", func(x any) { n.Label = as[*Message](x) })
+	}
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*Location](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkNotification(n *Notification, ptr string) {
+	if n.AssociatedRule != nil {
+		w.walk(n.AssociatedRule, n, ptr+"/associatedRule", func(x any) { n.AssociatedRule = as[*ReportingDescriptorReference](x) })
+	}
+	if n.Descriptor != nil {
+		w.walk(n.Descriptor, n, ptr+"/descriptor", func(x any) { n.Descriptor = as[*ReportingDescriptorReference](x) })
+	}
+	if n.Exception != nil {
+		w.walk(n.Exception, n, ptr+"/exception", func(x any) { n.Exception = as[*Exception](x) })
+	}
+	for i := range n.Locations {
+		if n.Locations[i] != nil {
+			i := i
+			w.walk(n.Locations[i], n, ptr+"/locations"+"/"+strconv.Itoa(i), func(x any) { n.Locations[i] = as[*Location](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkPhysicalLocation(n *PhysicalLocation, ptr string) {
+	if n.Address != nil {
+		w.walk(n.Address, n, ptr+"/address", func(x any) { n.Address = as[*Address](x) })
+	}
+	if n.ArtifactLocation != nil {
+		w.walk(n.ArtifactLocation, n, ptr+"/artifactLocation", func(x any) { n.ArtifactLocation = as[*ArtifactLocation](x) })
+	}
+	if n.ContextRegion != nil {
+		w.walk(n.ContextRegion, n, ptr+"/contextRegion", func(x any) { n.ContextRegion = as[*Region](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Region != nil {
+		w.walk(n.Region, n, ptr+"/region", func(x any) { n.Region = as[*Region](x) })
+	}
+}
+
+func (w *walker) walkPropertyBag(n *PropertyBag, ptr string) {
+}
+
+func (w *walker) walkRectangle(n *Rectangle, ptr string) {
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkRegion(n *Region, ptr string) {
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Snippet != nil {
+		w.walk(n.Snippet, n, ptr+"/snippet", func(x any) { n.Snippet = as[*ArtifactContent](x) })
+	}
+}
+
+func (w *walker) walkReplacement(n *Replacement, ptr string) {
+	if n.DeletedRegion != nil {
+		w.walk(n.DeletedRegion, n, ptr+"/deletedRegion", func(x any) { n.DeletedRegion = as[*Region](x) })
+	}
+	if n.InsertedContent != nil {
+		w.walk(n.InsertedContent, n, ptr+"/insertedContent", func(x any) { n.InsertedContent = as[*ArtifactContent](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkReportingConfiguration(n *ReportingConfiguration, ptr string) {
+	if n.Parameters != nil {
+		w.walk(n.Parameters, n, ptr+"/parameters", func(x any) { n.Parameters = as[*PropertyBag](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkReportingDescriptor(n *ReportingDescriptor, ptr string) {
+	if n.DefaultConfiguration != nil {
+		w.walk(n.DefaultConfiguration, n, ptr+"/defaultConfiguration", func(x any) { n.DefaultConfiguration = as[*ReportingConfiguration](x) })
+	}
+	if n.FullDescription != nil {
+		w.walk(n.FullDescription, n, ptr+"/fullDescription", func(x any) { n.FullDescription = as[*MultiformatMessageString](x) })
+	}
+	if n.Help != nil {
+		w.walk(n.Help, n, ptr+"/help", func(x any) { n.Help = as[*MultiformatMessageString](x) })
+	}
+	for _, k := range sortedKeys(n.MessageStrings) {
+		if n.MessageStrings[k] != nil {
+			k := k
+			w.walk(n.MessageStrings[k], n, ptr+"/messageStrings"+"/"+escapePointer(k), func(x any) { n.MessageStrings[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Relationships {
+		if n.Relationships[i] != nil {
+			i := i
+			w.walk(n.Relationships[i], n, ptr+"/relationships"+"/"+strconv.Itoa(i), func(x any) { n.Relationships[i] = as[*ReportingDescriptorRelationship](x) })
+		}
+	}
+	if n.ShortDescription != nil {
+		w.walk(n.ShortDescription, n, ptr+"/shortDescription", func(x any) { n.ShortDescription = as[*MultiformatMessageString](x) })
+	}
+}
+
+func (w *walker) walkReportingDescriptorReference(n *ReportingDescriptorReference, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.ToolComponent != nil {
+		w.walk(n.ToolComponent, n, ptr+"/toolComponent", func(x any) { n.ToolComponent = as[*ToolComponentReference](x) })
+	}
+}
+
+func (w *walker) walkReportingDescriptorRelationship(n *ReportingDescriptorRelationship, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Target != nil {
+		w.walk(n.Target, n, ptr+"/target", func(x any) { n.Target = as[*ReportingDescriptorReference](x) })
+	}
+}
+
+func (w *walker) walkResult(n *Result, ptr string) {
+	if n.AnalysisTarget != nil {
+		w.walk(n.AnalysisTarget, n, ptr+"/analysisTarget", func(x any) { n.AnalysisTarget = as[*ArtifactLocation](x) })
+	}
+	for i := range n.Attachments {
+		if n.Attachments[i] != nil {
+			i := i
+			w.walk(n.Attachments[i], n, ptr+"/attachments"+"/"+strconv.Itoa(i), func(x any) { n.Attachments[i] = as[*Attachment](x) })
+		}
+	}
+	for i := range n.CodeFlows {
+		if n.CodeFlows[i] != nil {
+			i := i
+			w.walk(n.CodeFlows[i], n, ptr+"/codeFlows"+"/"+strconv.Itoa(i), func(x any) { n.CodeFlows[i] = as[*CodeFlow](x) })
+		}
+	}
+	for i := range n.Fixes {
+		if n.Fixes[i] != nil {
+			i := i
+			w.walk(n.Fixes[i], n, ptr+"/fixes"+"/"+strconv.Itoa(i), func(x any) { n.Fixes[i] = as[*Fix](x) })
+		}
+	}
+	for i := range n.GraphTraversals {
+		if n.GraphTraversals[i] != nil {
+			i := i
+			w.walk(n.GraphTraversals[i], n, ptr+"/graphTraversals"+"/"+strconv.Itoa(i), func(x any) { n.GraphTraversals[i] = as[*GraphTraversal](x) })
+		}
+	}
+	for i := range n.Graphs {
+		if n.Graphs[i] != nil {
+			i := i
+			w.walk(n.Graphs[i], n, ptr+"/graphs"+"/"+strconv.Itoa(i), func(x any) { n.Graphs[i] = as[*Graph](x) })
+		}
+	}
+	for i := range n.Locations {
+		if n.Locations[i] != nil {
+			i := i
+			w.walk(n.Locations[i], n, ptr+"/locations"+"/"+strconv.Itoa(i), func(x any) { n.Locations[i] = as[*Location](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Provenance != nil {
+		w.walk(n.Provenance, n, ptr+"/provenance", func(x any) { n.Provenance = as[*ResultProvenance](x) })
+	}
+	for i := range n.RelatedLocations {
+		if n.RelatedLocations[i] != nil {
+			i := i
+			w.walk(n.RelatedLocations[i], n, ptr+"/relatedLocations"+"/"+strconv.Itoa(i), func(x any) { n.RelatedLocations[i] = as[*Location](x) })
+		}
+	}
+	if n.Rule != nil {
+		w.walk(n.Rule, n, ptr+"/rule", func(x any) { n.Rule = as[*ReportingDescriptorReference](x) })
+	}
+	for i := range n.Stacks {
+		if n.Stacks[i] != nil {
+			i := i
+			w.walk(n.Stacks[i], n, ptr+"/stacks"+"/"+strconv.Itoa(i), func(x any) { n.Stacks[i] = as[*Stack](x) })
+		}
+	}
+	for i := range n.Suppressions {
+		if n.Suppressions[i] != nil {
+			i := i
+			w.walk(n.Suppressions[i], n, ptr+"/suppressions"+"/"+strconv.Itoa(i), func(x any) { n.Suppressions[i] = as[*Suppression](x) })
+		}
+	}
+	for i := range n.Taxa {
+		if n.Taxa[i] != nil {
+			i := i
+			w.walk(n.Taxa[i], n, ptr+"/taxa"+"/"+strconv.Itoa(i), func(x any) { n.Taxa[i] = as[*ReportingDescriptorReference](x) })
+		}
+	}
+	if n.WebRequest != nil {
+		w.walk(n.WebRequest, n, ptr+"/webRequest", func(x any) { n.WebRequest = as[*WebRequest](x) })
+	}
+	if n.WebResponse != nil {
+		w.walk(n.WebResponse, n, ptr+"/webResponse", func(x any) { n.WebResponse = as[*WebResponse](x) })
+	}
+}
+
+func (w *walker) walkResultProvenance(n *ResultProvenance, ptr string) {
+	for i := range n.ConversionSources {
+		if n.ConversionSources[i] != nil {
+			i := i
+			w.walk(n.ConversionSources[i], n, ptr+"/conversionSources"+"/"+strconv.Itoa(i), func(x any) { n.ConversionSources[i] = as[*PhysicalLocation](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkRun(n *Run, ptr string) {
+	for i := range n.Addresses {
+		if n.Addresses[i] != nil {
+			i := i
+			w.walk(n.Addresses[i], n, ptr+"/addresses"+"/"+strconv.Itoa(i), func(x any) { n.Addresses[i] = as[*Address](x) })
+		}
+	}
+	for i := range n.Artifacts {
+		if n.Artifacts[i] != nil {
+			i := i
+			w.walk(n.Artifacts[i], n, ptr+"/artifacts"+"/"+strconv.Itoa(i), func(x any) { n.Artifacts[i] = as[*Artifact](x) })
+		}
+	}
+	if n.AutomationDetails != nil {
+		w.walk(n.AutomationDetails, n, ptr+"/automationDetails", func(x any) { n.AutomationDetails = as[*RunAutomationDetails](x) })
+	}
+	if n.Conversion != nil {
+		w.walk(n.Conversion, n, ptr+"/conversion", func(x any) { n.Conversion = as[*Conversion](x) })
+	}
+	if n.ExternalPropertyFileReferences != nil {
+		w.walk(n.ExternalPropertyFileReferences, n, ptr+"/externalPropertyFileReferences", func(x any) { n.ExternalPropertyFileReferences = as[*ExternalPropertyFileReferences](x) })
+	}
+	for i := range n.Graphs {
+		if n.Graphs[i] != nil {
+			i := i
+			w.walk(n.Graphs[i], n, ptr+"/graphs"+"/"+strconv.Itoa(i), func(x any) { n.Graphs[i] = as[*Graph](x) })
+		}
+	}
+	for i := range n.Invocations {
+		if n.Invocations[i] != nil {
+			i := i
+			w.walk(n.Invocations[i], n, ptr+"/invocations"+"/"+strconv.Itoa(i), func(x any) { n.Invocations[i] = as[*Invocation](x) })
+		}
+	}
+	for i := range n.LogicalLocations {
+		if n.LogicalLocations[i] != nil {
+			i := i
+			w.walk(n.LogicalLocations[i], n, ptr+"/logicalLocations"+"/"+strconv.Itoa(i), func(x any) { n.LogicalLocations[i] = as[*LogicalLocation](x) })
+		}
+	}
+	for _, k := range sortedKeys(n.OriginalUriBaseIds) {
+		if n.OriginalUriBaseIds[k] != nil {
+			k := k
+			w.walk(n.OriginalUriBaseIds[k], n, ptr+"/originalUriBaseIds"+"/"+escapePointer(k), func(x any) { n.OriginalUriBaseIds[k] = as[*ArtifactLocation](x) })
+		}
+	}
+	for i := range n.Policies {
+		if n.Policies[i] != nil {
+			i := i
+			w.walk(n.Policies[i], n, ptr+"/policies"+"/"+strconv.Itoa(i), func(x any) { n.Policies[i] = as[*ToolComponent](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Results {
+		if n.Results[i] != nil {
+			i := i
+			w.walk(n.Results[i], n, ptr+"/results"+"/"+strconv.Itoa(i), func(x any) { n.Results[i] = as[*Result](x) })
+		}
+	}
+	for i := range n.RunAggregates {
+		if n.RunAggregates[i] != nil {
+			i := i
+			w.walk(n.RunAggregates[i], n, ptr+"/runAggregates"+"/"+strconv.Itoa(i), func(x any) { n.RunAggregates[i] = as[*RunAutomationDetails](x) })
+		}
+	}
+	if n.SpecialLocations != nil {
+		w.walk(n.SpecialLocations, n, ptr+"/specialLocations", func(x any) { n.SpecialLocations = as[*SpecialLocations](x) })
+	}
+	for i := range n.Taxonomies {
+		if n.Taxonomies[i] != nil {
+			i := i
+			w.walk(n.Taxonomies[i], n, ptr+"/taxonomies"+"/"+strconv.Itoa(i), func(x any) { n.Taxonomies[i] = as[*ToolComponent](x) })
+		}
+	}
+	for i := range n.ThreadFlowLocations {
+		if n.ThreadFlowLocations[i] != nil {
+			i := i
+			w.walk(n.ThreadFlowLocations[i], n, ptr+"/threadFlowLocations"+"/"+strconv.Itoa(i), func(x any) { n.ThreadFlowLocations[i] = as[*ThreadFlowLocation](x) })
+		}
+	}
+	if n.Tool != nil {
+		w.walk(n.Tool, n, ptr+"/tool", func(x any) { n.Tool = as[*Tool](x) })
+	}
+	for i := range n.Translations {
+		if n.Translations[i] != nil {
+			i := i
+			w.walk(n.Translations[i], n, ptr+"/translations"+"/"+strconv.Itoa(i), func(x any) { n.Translations[i] = as[*ToolComponent](x) })
+		}
+	}
+	for i := range n.VersionControlProvenance {
+		if n.VersionControlProvenance[i] != nil {
+			i := i
+			w.walk(n.VersionControlProvenance[i], n, ptr+"/versionControlProvenance"+"/"+strconv.Itoa(i), func(x any) { n.VersionControlProvenance[i] = as[*VersionControlDetails](x) })
+		}
+	}
+	for i := range n.WebRequests {
+		if n.WebRequests[i] != nil {
+			i := i
+			w.walk(n.WebRequests[i], n, ptr+"/webRequests"+"/"+strconv.Itoa(i), func(x any) { n.WebRequests[i] = as[*WebRequest](x) })
+		}
+	}
+	for i := range n.WebResponses {
+		if n.WebResponses[i] != nil {
+			i := i
+			w.walk(n.WebResponses[i], n, ptr+"/webResponses"+"/"+strconv.Itoa(i), func(x any) { n.WebResponses[i] = as[*WebResponse](x) })
+		}
+	}
+}
+
+func (w *walker) walkRunAutomationDetails(n *RunAutomationDetails, ptr string) {
+	if n.Description != nil {
+		w.walk(n.Description, n, ptr+"/description", func(x any) { n.Description = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkSARIF(n *SARIF, ptr string) {
+	for i := range n.InlineExternalProperties {
+		if n.InlineExternalProperties[i] != nil {
+			i := i
+			w.walk(n.InlineExternalProperties[i], n, ptr+"/inlineExternalProperties"+"/"+strconv.Itoa(i), func(x any) { n.InlineExternalProperties[i] = as[*ExternalProperties](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Runs {
+		if n.Runs[i] != nil {
+			i := i
+			w.walk(n.Runs[i], n, ptr+"/runs"+"/"+strconv.Itoa(i), func(x any) { n.Runs[i] = as[*Run](x) })
+		}
+	}
+}
+
+func (w *walker) walkSpecialLocations(n *SpecialLocations, ptr string) {
+	if n.DisplayBase != nil {
+		w.walk(n.DisplayBase, n, ptr+"/displayBase", func(x any) { n.DisplayBase = as[*ArtifactLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkStack(n *Stack, ptr string) {
+	for i := range n.Frames {
+		if n.Frames[i] != nil {
+			i := i
+			w.walk(n.Frames[i], n, ptr+"/frames"+"/"+strconv.Itoa(i), func(x any) { n.Frames[i] = as[*StackFrame](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkStackFrame(n *StackFrame, ptr string) {
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*Location](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkSuppression(n *Suppression, ptr string) {
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*Location](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkThreadFlow(n *ThreadFlow, ptr string) {
+	for _, k := range sortedKeys(n.ImmutableState) {
+		if n.ImmutableState[k] != nil {
+			k := k
+			w.walk(n.ImmutableState[k], n, ptr+"/immutableState"+"/"+escapePointer(k), func(x any) { n.ImmutableState[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	for _, k := range sortedKeys(n.InitialState) {
+		if n.InitialState[k] != nil {
+			k := k
+			w.walk(n.InitialState[k], n, ptr+"/initialState"+"/"+escapePointer(k), func(x any) { n.InitialState[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	for i := range n.Locations {
+		if n.Locations[i] != nil {
+			i := i
+			w.walk(n.Locations[i], n, ptr+"/locations"+"/"+strconv.Itoa(i), func(x any) { n.Locations[i] = as[*ThreadFlowLocation](x) })
+		}
+	}
+	if n.Message != nil {
+		w.walk(n.Message, n, ptr+"/message", func(x any) { n.Message = as[*Message](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkThreadFlowLocation(n *ThreadFlowLocation, ptr string) {
+	if n.Location != nil {
+		w.walk(n.Location, n, ptr+"/location", func(x any) { n.Location = as[*Location](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.Stack != nil {
+		w.walk(n.Stack, n, ptr+"/stack", func(x any) { n.Stack = as[*Stack](x) })
+	}
+	for _, k := range sortedKeys(n.State) {
+		if n.State[k] != nil {
+			k := k
+			w.walk(n.State[k], n, ptr+"/state"+"/"+escapePointer(k), func(x any) { n.State[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	for i := range n.Taxa {
+		if n.Taxa[i] != nil {
+			i := i
+			w.walk(n.Taxa[i], n, ptr+"/taxa"+"/"+strconv.Itoa(i), func(x any) { n.Taxa[i] = as[*ReportingDescriptorReference](x) })
+		}
+	}
+	if n.WebRequest != nil {
+		w.walk(n.WebRequest, n, ptr+"/webRequest", func(x any) { n.WebRequest = as[*WebRequest](x) })
+	}
+	if n.WebResponse != nil {
+		w.walk(n.WebResponse, n, ptr+"/webResponse", func(x any) { n.WebResponse = as[*WebResponse](x) })
+	}
+}
+
+func (w *walker) walkTool(n *Tool, ptr string) {
+	if n.Driver != nil {
+		w.walk(n.Driver, n, ptr+"/driver", func(x any) { n.Driver = as[*ToolComponent](x) })
+	}
+	for i := range n.Extensions {
+		if n.Extensions[i] != nil {
+			i := i
+			w.walk(n.Extensions[i], n, ptr+"/extensions"+"/"+strconv.Itoa(i), func(x any) { n.Extensions[i] = as[*ToolComponent](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkToolComponent(n *ToolComponent, ptr string) {
+	if n.AssociatedComponent != nil {
+		w.walk(n.AssociatedComponent, n, ptr+"/associatedComponent", func(x any) { n.AssociatedComponent = as[*ToolComponentReference](x) })
+	}
+	if n.FullDescription != nil {
+		w.walk(n.FullDescription, n, ptr+"/fullDescription", func(x any) { n.FullDescription = as[*MultiformatMessageString](x) })
+	}
+	for _, k := range sortedKeys(n.GlobalMessageStrings) {
+		if n.GlobalMessageStrings[k] != nil {
+			k := k
+			w.walk(n.GlobalMessageStrings[k], n, ptr+"/globalMessageStrings"+"/"+escapePointer(k), func(x any) { n.GlobalMessageStrings[k] = as[*MultiformatMessageString](x) })
+		}
+	}
+	for i := range n.Locations {
+		if n.Locations[i] != nil {
+			i := i
+			w.walk(n.Locations[i], n, ptr+"/locations"+"/"+strconv.Itoa(i), func(x any) { n.Locations[i] = as[*ArtifactLocation](x) })
+		}
+	}
+	for i := range n.Notifications {
+		if n.Notifications[i] != nil {
+			i := i
+			w.walk(n.Notifications[i], n, ptr+"/notifications"+"/"+strconv.Itoa(i), func(x any) { n.Notifications[i] = as[*ReportingDescriptor](x) })
+		}
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	for i := range n.Rules {
+		if n.Rules[i] != nil {
+			i := i
+			w.walk(n.Rules[i], n, ptr+"/rules"+"/"+strconv.Itoa(i), func(x any) { n.Rules[i] = as[*ReportingDescriptor](x) })
+		}
+	}
+	if n.ShortDescription != nil {
+		w.walk(n.ShortDescription, n, ptr+"/shortDescription", func(x any) { n.ShortDescription = as[*MultiformatMessageString](x) })
+	}
+	for i := range n.SupportedTaxonomies {
+		if n.SupportedTaxonomies[i] != nil {
+			i := i
+			w.walk(n.SupportedTaxonomies[i], n, ptr+"/supportedTaxonomies"+"/"+strconv.Itoa(i), func(x any) { n.SupportedTaxonomies[i] = as[*ToolComponentReference](x) })
+		}
+	}
+	for i := range n.Taxa {
+		if n.Taxa[i] != nil {
+			i := i
+			w.walk(n.Taxa[i], n, ptr+"/taxa"+"/"+strconv.Itoa(i), func(x any) { n.Taxa[i] = as[*ReportingDescriptor](x) })
+		}
+	}
+	if n.TranslationMetadata != nil {
+		w.walk(n.TranslationMetadata, n, ptr+"/translationMetadata", func(x any) { n.TranslationMetadata = as[*TranslationMetadata](x) })
+	}
+}
+
+func (w *walker) walkToolComponentReference(n *ToolComponentReference, ptr string) {
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkTranslationMetadata(n *TranslationMetadata, ptr string) {
+	if n.FullDescription != nil {
+		w.walk(n.FullDescription, n, ptr+"/fullDescription", func(x any) { n.FullDescription = as[*MultiformatMessageString](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+	if n.ShortDescription != nil {
+		w.walk(n.ShortDescription, n, ptr+"/shortDescription", func(x any) { n.ShortDescription = as[*MultiformatMessageString](x) })
+	}
+}
+
+func (w *walker) walkVersionControlDetails(n *VersionControlDetails, ptr string) {
+	if n.MappedTo != nil {
+		w.walk(n.MappedTo, n, ptr+"/mappedTo", func(x any) { n.MappedTo = as[*ArtifactLocation](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkWebRequest(n *WebRequest, ptr string) {
+	if n.Body != nil {
+		w.walk(n.Body, n, ptr+"/body", func(x any) { n.Body = as[*ArtifactContent](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
+
+func (w *walker) walkWebResponse(n *WebResponse, ptr string) {
+	if n.Body != nil {
+		w.walk(n.Body, n, ptr+"/body", func(x any) { n.Body = as[*ArtifactContent](x) })
+	}
+	if n.Properties != nil {
+		w.walk(n.Properties, n, ptr+"/properties", func(x any) { n.Properties = as[*PropertyBag](x) })
+	}
+}
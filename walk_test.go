@@ -0,0 +1,120 @@
+package sarif
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspectPointers(t *testing.T) {
+	log := &SARIF{Runs: []*Run{{
+		Tool: &Tool{Driver: &ToolComponent{Name: "t"}},
+		OriginalUriBaseIds: map[string]*ArtifactLocation{
+			"b/c": {Uri: "file:///b/"},
+			"a~":  {Uri: "file:///a/"},
+		},
+		Results: []*Result{
+			nil,
+			{Message: &Message{Text: "m"}},
+		},
+	}}}
+	tests := []struct {
+		name  string
+		prune func(c *Cursor) bool
+		want  []string
+	}{
+		{
+			name:  "everything",
+			prune: func(*Cursor) bool { return true },
+			want: []string{
+				"",
+				"/runs/0",
+				"/runs/0/originalUriBaseIds/a~0",
+				"/runs/0/originalUriBaseIds/b~1c",
+				"/runs/0/results/1",
+				"/runs/0/results/1/message",
+				"/runs/0/tool",
+				"/runs/0/tool/driver",
+			},
+		},
+		{
+			name:  "pruned at the results",
+			prune: func(c *Cursor) bool { _, ok := c.Node().(*Result); return !ok },
+			want: []string{
+				"",
+				"/runs/0",
+				"/runs/0/originalUriBaseIds/a~0",
+				"/runs/0/originalUriBaseIds/b~1c",
+				"/runs/0/results/1",
+				"/runs/0/tool",
+				"/runs/0/tool/driver",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			Inspect(log, func(c *Cursor) bool {
+				got = append(got, c.Pointer())
+				return tt.prune(c)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("visited %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInspectParent(t *testing.T) {
+	msg := &Message{Text: "m"}
+	result := &Result{Message: msg}
+	Inspect(result, func(c *Cursor) bool {
+		switch c.Node() {
+		case result:
+			if c.Parent() != nil {
+				t.Errorf("parent of the root = %v, want nil", c.Parent())
+			}
+		case msg:
+			if c.Parent() != result {
+				t.Errorf("parent of the message = %v, want the result", c.Parent())
+			}
+		}
+		return true
+	})
+}
+
+func TestCursorReplace(t *testing.T) {
+	tests := []struct {
+		name string
+		with any
+		want *Message
+	}{
+		{"replace", &Message{Text: "new"}, &Message{Text: "new"}},
+		{"delete", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &Result{Message: &Message{Text: "old"}}
+			Inspect(result, func(c *Cursor) bool {
+				if _, ok := c.Node().(*Message); ok {
+					c.Replace(tt.with)
+				}
+				return true
+			})
+			if !reflect.DeepEqual(result.Message, tt.want) {
+				t.Errorf("message = %+v, want %+v", result.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorReplaceRoot(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("replacing the root did not panic")
+		}
+	}()
+	Inspect(&Result{}, func(c *Cursor) bool {
+		c.Replace(&Result{})
+		return true
+	})
+}